@@ -19,18 +19,18 @@ import (
 	"fmt"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -42,49 +42,142 @@ var (
 )
 
 type APIClient struct {
-	authenticationSettings *settings.AuthenticationSettings
-	authenticationToken    *string
-	httpSettings           *settings.HttpSettings
-	httpClient             *http.Client
-	mutex                  sync.Mutex
+	authProvider AuthProvider
+	httpSettings *settings.HttpSettings
+	httpClient   *http.Client
+	// httpClientError Invalid http settings, returned by every request
+	httpClientError error
 }
 
+// NewAPIClient Creates a client authenticated with the key id and secret of the authenticationSettings, when not empty
 func NewAPIClient(
 	authenticationSettings *settings.AuthenticationSettings,
 	httpSettings *settings.HttpSettings,
+) *APIClient {
+	client := NewAPIClientWithAuthProvider(nil, httpSettings)
+	if authenticationSettings != nil && !authenticationSettings.IsEmpty() {
+		client.authProvider = newKeySecretAuthProvider(client, authenticationSettings)
+	}
+	return client
+}
+
+// NewAPIClientWithAuthProvider Creates a client authenticated by the authProvider, nil to send requests without authentication
+func NewAPIClientWithAuthProvider(
+	authProvider AuthProvider,
+	httpSettings *settings.HttpSettings,
 ) *APIClient {
 	if httpSettings == nil {
 		httpSettings = settings.NewHttpDefaultSettings()
 	}
+	httpClient, err := newHttpClient(httpSettings)
+	if err != nil {
+		log.Error("Invalid http settings, every request will fail, reason: ", err.Error())
+	}
+	if httpClientAware, ok := authProvider.(httpClientAwareAuthProvider); ok && httpClient != nil {
+		httpClientAware.setDefaultHttpClient(httpClient)
+	}
+	return &APIClient{
+		authProvider:    authProvider,
+		httpSettings:    httpSettings,
+		httpClient:      httpClient,
+		httpClientError: err,
+	}
+}
 
-	baseDialer := &net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
+// callAPI do the request, retrying it as set by the RetryPolicy of the http settings.
+func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
+	if c.httpClientError != nil {
+		return nil, c.httpClientError
+	}
+	policy := c.getRetryPolicy()
+	for attempt := 1; ; attempt += 1 {
+		response, err := c.doAuthenticatedRequest(request)
+		if attempt >= policy.MaxAttempts || !shouldRetry(policy, request, response, err) {
+			return response, err
+		}
+		retryRequest, copyErr := copyRequest(request)
+		if copyErr != nil {
+			log.Debug("Unable to retry request to: ", request.URL.Path, ", reason: ", copyErr.Error())
+			return response, err
+		}
+		delay := getRetryDelay(policy, attempt, response)
+		if err != nil {
+			log.Debug("Retrying request to: ", request.URL.Path, ", attempt: ", attempt, ", in: ", delay, ", reason: ", err.Error())
+		} else {
+			log.Debug("Retrying request to: ", request.URL.Path, ", attempt: ", attempt, ", in: ", delay, ", status: ", response.Status)
+		}
+		discardResponse(response)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		}
+		request = retryRequest
 	}
+}
 
-	netTransport := &http.Transport{
-		DialContext:         baseDialer.DialContext,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
-		DisableCompression:  false,
+func (c *APIClient) getRetryPolicy() *settings.RetryPolicy {
+	if c.httpSettings.RetryPolicy == nil {
+		return settings.NewDefaultRetryPolicy()
 	}
-	client := http.Client{
-		Transport:     netTransport,
-		CheckRedirect: nil,
-		Jar:           nil,
-		Timeout:       30 * time.Second,
+	return c.httpSettings.RetryPolicy
+}
+
+// doAuthenticatedRequest do the request, retrying it once with fresh credentials when the server rejects the current ones.
+func (c *APIClient) doAuthenticatedRequest(request *http.Request) (*http.Response, error) {
+	response, err := c.doRequest(request)
+	if err != nil || c.authProvider == nil || !isAuthenticationFailure(response.StatusCode) {
+		return response, err
 	}
-	return &APIClient{
-		authenticationSettings: authenticationSettings,
-		authenticationToken:    nil,
-		httpSettings:           httpSettings,
-		httpClient:             &client,
+	if !c.authProvider.Invalidate(request) {
+		return response, err
+	}
+	log.Debug("Credentials rejected, status: ", response.Status, ", retrying with fresh credentials")
+	response.Body.Close()
+	retryRequest, err := c.reauthenticate(request)
+	if err != nil {
+		return response, err
 	}
+	response, err = c.doRequest(retryRequest)
+	if err != nil || !isAuthenticationFailure(response.StatusCode) {
+		return response, err
+	}
+	return response, newAuthenticationErrorFromResponse(response)
 }
 
-// callAPI do the request.
-func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
-	return c.httpClient.Do(request)
+// doRequest do the request, traced as a client span when tracing is enabled.
+func (c *APIClient) doRequest(request *http.Request) (*http.Response, error) {
+	if c.httpClientError != nil {
+		return nil, c.httpClientError
+	}
+	if !tracing.IsEnabled() {
+		return c.sendRequest(request)
+	}
+	ctx, span := tracing.StartSpan(request.Context(), "HTTP "+request.Method)
+	span.SetAttribute("http.method", request.Method)
+	span.SetAttribute("http.url", request.URL.Path)
+	request.Header.Set(tracing.TraceParentKey, tracing.FormatTraceParent(tracing.SpanContextFromContext(ctx)))
+	response, err := c.sendRequest(request)
+	spanError := err
+	if err == nil {
+		span.SetAttribute("http.status_code", strconv.Itoa(response.StatusCode))
+		if response.StatusCode >= 400 {
+			spanError = errors.New(response.Status)
+		}
+	}
+	span.End(spanError)
+	return response, err
+}
+
+// sendRequest send the request, wrapping the failures to get a response in a TransportError
+func (c *APIClient) sendRequest(request *http.Request) (*http.Response, error) {
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return response, &TransportError{Err: err}
+	}
+	return response, nil
 }
 
 // prepareRequest build the request
@@ -164,16 +257,6 @@ func (c *APIClient) prepareRequest(
 		headerParams["Content-Length"] = fmt.Sprintf("%d", body.Len())
 	}
 
-	// Auth
-	c.mutex.Lock()
-	if c.mustRefreshToken() {
-		c.refreshToken()
-	}
-	c.mutex.Unlock()
-	if c.authenticationToken != nil {
-		headerParams["X-Authorization"] = *c.authenticationToken
-	}
-
 	// Setup path and query parameters
 	url, err := url.Parse(c.httpSettings.BaseUrl + path)
 	if err != nil {
@@ -200,6 +283,9 @@ func (c *APIClient) prepareRequest(
 	if err != nil {
 		return nil, err
 	}
+	if ctx != nil {
+		localVarRequest = localVarRequest.WithContext(ctx)
+	}
 
 	// add header parameters, if any
 	if len(headerParams) > 0 {
@@ -214,31 +300,31 @@ func (c *APIClient) prepareRequest(
 		localVarRequest.Header.Add(header, value)
 	}
 
+	// Auth
+	if c.authProvider != nil {
+		err = c.authProvider.Authenticate(localVarRequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return localVarRequest, nil
 }
 
-func (c *APIClient) mustRefreshToken() bool {
-	if c.authenticationSettings == nil || c.authenticationSettings.IsEmpty() {
-		return false
+// reauthenticate Copies the request, authenticated again by the AuthProvider
+func (c *APIClient) reauthenticate(request *http.Request) (*http.Request, error) {
+	retryRequest, err := copyRequest(request)
+	if err != nil {
+		return nil, err
 	}
-	return c.authenticationToken == nil
-}
-
-func (c *APIClient) refreshToken() {
-	log.Debug("Refreshing authentication token")
-	token, response, err := c.getToken()
+	err = c.authProvider.Authenticate(retryRequest)
 	if err != nil {
-		log.Warning(
-			"Failed to refresh authentication token",
-			", response: ", response,
-			", error: ", err,
-		)
-	} else {
-		c.authenticationToken = &token.Token
+		return nil, err
 	}
+	return retryRequest, nil
 }
 
-func (c *APIClient) getToken() (model.Token, *http.Response, error) {
+func (c *APIClient) getToken(authenticationSettings *settings.AuthenticationSettings) (model.Token, *http.Response, error) {
 	var (
 		localVarHttpMethod  = strings.ToUpper("Post")
 		localVarPostBody    interface{}
@@ -260,12 +346,12 @@ func (c *APIClient) getToken() (model.Token, *http.Response, error) {
 	if localVarHttpHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHttpHeaderAccept
 	}
-	localVarPostBody = c.authenticationSettings.GetBody()
+	localVarPostBody = authenticationSettings.GetBody()
 	r, err := c.prepareRefreshTokenRequest(context.Background(), localVarPath, localVarHttpMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
 	}
-	localVarHttpResponse, err := c.callAPI(r)
+	localVarHttpResponse, err := c.doRequest(r)
 	if err != nil || localVarHttpResponse == nil {
 		return localVarReturnValue, localVarHttpResponse, err
 	}
@@ -281,21 +367,7 @@ func (c *APIClient) getToken() (model.Token, *http.Response, error) {
 		}
 	}
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: localVarHttpResponse.Status,
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v model.Task
-			err = c.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 	return localVarReturnValue, localVarHttpResponse, nil
 }
@@ -495,6 +567,9 @@ func CacheExpires(r *http.Response) time.Time {
 }
 
 // GenericSwaggerError Provides access to the body, error and model on returned errors.
+//
+// Deprecated: error responses are returned as APIError and the more specific errors wrapping it.
+// A GenericSwaggerError is still extracted from them with errors.As, while type assertions no longer match
 type GenericSwaggerError struct {
 	body  []byte
 	error string
@@ -0,0 +1,167 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Sentinel errors matched with errors.Is by the typed errors of the API
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrServer       = errors.New("server error")
+	ErrTransport    = errors.New("transport error")
+)
+
+// APIError Error response of the server, with the details of its JSON body when present.
+// Responses with a status of 401, 403, 404, 409 or 5xx are returned as the more specific errors below, all unwrapping to APIError
+type APIError struct {
+	StatusCode       int
+	Status           string
+	Message          string
+	ValidationErrors []model.ValidationError
+	// Retryable Whether the server reported the request is worth retrying
+	Retryable bool
+	body      []byte
+}
+
+func (e *APIError) Error() string {
+	message := e.Status
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	for _, validationError := range e.ValidationErrors {
+		message += fmt.Sprintf(", %s: %s", validationError.Path, validationError.Message)
+	}
+	return message
+}
+
+// Body returns the raw bytes of the response
+func (e *APIError) Body() []byte {
+	return e.body
+}
+
+// As Fills a GenericSwaggerError target with the status and body of the response, for code written before APIError
+func (e *APIError) As(target interface{}) bool {
+	swaggerError, ok := target.(*GenericSwaggerError)
+	if !ok {
+		return false
+	}
+	*swaggerError = GenericSwaggerError{
+		body:  e.body,
+		error: e.Status,
+	}
+	return true
+}
+
+// NotFoundError The requested entity does not exist, status 404
+type NotFoundError struct {
+	*APIError
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.APIError
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ConflictError The entity already exists or was changed concurrently, status 409
+type ConflictError struct {
+	*APIError
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.APIError
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// UnauthorizedError The request is not authenticated or not allowed, status 401 or 403
+type UnauthorizedError struct {
+	*APIError
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return e.APIError
+}
+
+func (e *UnauthorizedError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// ServerError The server failed to handle the request, status 5xx
+type ServerError struct {
+	*APIError
+}
+
+func (e *ServerError) Unwrap() error {
+	return e.APIError
+}
+
+func (e *ServerError) Is(target error) bool {
+	return target == ErrServer
+}
+
+// TransportError The request did not get a response from the server, e.g. it is unreachable or timed out
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return "transport error: " + e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// newAPIError Builds the typed error of a response with a status of 300 or more
+func newAPIError(response *http.Response, body []byte) error {
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		body:       body,
+	}
+	var errorResponse model.ErrorResponse
+	if json.Unmarshal(body, &errorResponse) == nil && (errorResponse.Message != "" || len(errorResponse.ValidationErrors) > 0) {
+		apiError.Message = errorResponse.Message
+		apiError.ValidationErrors = errorResponse.ValidationErrors
+		apiError.Retryable = errorResponse.Retryable
+	} else {
+		apiError.Message = strings.TrimSpace(string(body))
+	}
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return &NotFoundError{apiError}
+	case response.StatusCode == http.StatusConflict:
+		return &ConflictError{apiError}
+	case isAuthenticationFailure(response.StatusCode):
+		return &UnauthorizedError{apiError}
+	case response.StatusCode >= http.StatusInternalServerError:
+		return &ServerError{apiError}
+	}
+	return apiError
+}
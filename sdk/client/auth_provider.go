@@ -0,0 +1,234 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const authorizationHeader = "X-Authorization"
+
+// AuthProvider Authenticates the requests sent by the APIClient
+type AuthProvider interface {
+	// Authenticate Adds the credentials to the request, or returns an AuthenticationError when they can not be obtained
+	Authenticate(request *http.Request) error
+	// Invalidate Drops the credentials of a request rejected by the server with 401 or 403.
+	// Returns whether the request should be authenticated again and retried once
+	Invalidate(request *http.Request) bool
+}
+
+// keySecretAuthProvider Exchanges the key id and secret for a token at the /token endpoint of the server,
+// sent in the X-Authorization header.  Used by NewAPIClient
+type keySecretAuthProvider struct {
+	tokenCache *tokenCache
+}
+
+func newKeySecretAuthProvider(c *APIClient, authenticationSettings *settings.AuthenticationSettings) *keySecretAuthProvider {
+	return &keySecretAuthProvider{
+		tokenCache: newTokenCache(func() (string, time.Time, error) {
+			token, response, err := c.getToken(authenticationSettings)
+			if err != nil {
+				authenticationError := &AuthenticationError{Err: err}
+				if response != nil {
+					authenticationError.StatusCode = response.StatusCode
+				}
+				return "", time.Time{}, authenticationError
+			}
+			expiry, err := getTokenExpiry(token.Token)
+			if err != nil {
+				log.Debug("Unable to decode authentication token expiry, reason: ", err.Error())
+			}
+			return token.Token, expiry, nil
+		}),
+	}
+}
+
+func (p *keySecretAuthProvider) Authenticate(request *http.Request) error {
+	token, err := p.tokenCache.get()
+	if err != nil {
+		return err
+	}
+	request.Header.Set(authorizationHeader, token)
+	return nil
+}
+
+func (p *keySecretAuthProvider) Invalidate(request *http.Request) bool {
+	p.tokenCache.invalidate(request.Header.Get(authorizationHeader))
+	return true
+}
+
+// BearerTokenAuthProvider Sends a static token in the Authorization header, as issued by an API gateway
+type BearerTokenAuthProvider struct {
+	token string
+}
+
+func NewBearerTokenAuthProvider(token string) *BearerTokenAuthProvider {
+	return &BearerTokenAuthProvider{
+		token: token,
+	}
+}
+
+func (p *BearerTokenAuthProvider) Authenticate(request *http.Request) error {
+	request.Header.Set("Authorization", "Bearer "+p.token)
+	return nil
+}
+
+func (p *BearerTokenAuthProvider) Invalidate(request *http.Request) bool {
+	return false
+}
+
+// httpClientAwareAuthProvider AuthProvider sending its own requests, with the http client of the APIClient unless set
+type httpClientAwareAuthProvider interface {
+	setDefaultHttpClient(httpClient *http.Client)
+}
+
+// OAuth2ClientCredentialsAuthProvider Obtains access tokens from an OAuth2 token endpoint with the client credentials grant,
+// sent in the Authorization header and refreshed before they expire.
+// The token endpoint is reached with the http client of the APIClient, built from its HttpSettings, unless SetHttpClient is used
+type OAuth2ClientCredentialsAuthProvider struct {
+	tokenUrl     string
+	clientId     string
+	clientSecret string
+	scopes       []string
+	tokenCache   *tokenCache
+
+	httpClientMutex sync.RWMutex
+	httpClient      *http.Client
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func NewOAuth2ClientCredentialsAuthProvider(tokenUrl string, clientId string, clientSecret string, scopes ...string) *OAuth2ClientCredentialsAuthProvider {
+	p := &OAuth2ClientCredentialsAuthProvider{
+		tokenUrl:     tokenUrl,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+	}
+	p.tokenCache = newTokenCache(p.fetchToken)
+	return p
+}
+
+// SetHttpClient Sets the client used to reach the token endpoint, e.g. when it needs different proxy or TLS settings than the server
+func (p *OAuth2ClientCredentialsAuthProvider) SetHttpClient(httpClient *http.Client) {
+	p.httpClientMutex.Lock()
+	defer p.httpClientMutex.Unlock()
+	p.httpClient = httpClient
+}
+
+func (p *OAuth2ClientCredentialsAuthProvider) setDefaultHttpClient(httpClient *http.Client) {
+	p.httpClientMutex.Lock()
+	defer p.httpClientMutex.Unlock()
+	if p.httpClient == nil {
+		p.httpClient = httpClient
+	}
+}
+
+func (p *OAuth2ClientCredentialsAuthProvider) getHttpClient() *http.Client {
+	p.httpClientMutex.RLock()
+	defer p.httpClientMutex.RUnlock()
+	if p.httpClient == nil {
+		return &http.Client{Timeout: defaultHttpTimeout}
+	}
+	return p.httpClient
+}
+
+func (p *OAuth2ClientCredentialsAuthProvider) Authenticate(request *http.Request) error {
+	token, err := p.tokenCache.get()
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (p *OAuth2ClientCredentialsAuthProvider) Invalidate(request *http.Request) bool {
+	p.tokenCache.invalidate(strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer "))
+	return true
+}
+
+func (p *OAuth2ClientCredentialsAuthProvider) fetchToken() (string, time.Time, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(p.scopes) > 0 {
+		form.Set("scope", strings.Join(p.scopes, " "))
+	}
+	request, err := http.NewRequest(http.MethodPost, p.tokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, &AuthenticationError{Err: err}
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(p.clientId), url.QueryEscape(p.clientSecret))
+	response, err := p.getHttpClient().Do(request)
+	if err != nil {
+		return "", time.Time{}, &AuthenticationError{Err: err}
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", time.Time{}, &AuthenticationError{StatusCode: response.StatusCode, Err: err}
+	}
+	if response.StatusCode >= 300 {
+		return "", time.Time{}, &AuthenticationError{StatusCode: response.StatusCode, Message: string(body)}
+	}
+	var tokenResponse oauth2TokenResponse
+	err = json.Unmarshal(body, &tokenResponse)
+	if err != nil {
+		return "", time.Time{}, &AuthenticationError{StatusCode: response.StatusCode, Err: err}
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", time.Time{}, &AuthenticationError{StatusCode: response.StatusCode, Message: "token response without access_token"}
+	}
+	var expiry time.Time
+	if tokenResponse.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return tokenResponse.AccessToken, expiry, nil
+}
+
+// HeaderSignerAuthProvider Authenticates the requests with a custom function, e.g. signing them for a gateway.
+// The function must only change the headers of the request
+type HeaderSignerAuthProvider struct {
+	sign func(request *http.Request) error
+}
+
+func NewHeaderSignerAuthProvider(sign func(request *http.Request) error) *HeaderSignerAuthProvider {
+	return &HeaderSignerAuthProvider{
+		sign: sign,
+	}
+}
+
+func (p *HeaderSignerAuthProvider) Authenticate(request *http.Request) error {
+	err := p.sign(request)
+	if err != nil {
+		return &AuthenticationError{Message: fmt.Sprintf("failed to sign request to %s", request.URL.Path), Err: err}
+	}
+	return nil
+}
+
+func (p *HeaderSignerAuthProvider) Invalidate(request *http.Request) bool {
+	return false
+}
@@ -0,0 +1,149 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tokenRefreshMargin How long before its expiry the authentication token is refreshed
+const tokenRefreshMargin = 60 * time.Second
+
+// AuthenticationError Failure to authenticate with the server, either obtaining a token or using it.
+// Returned instead of sending requests without authentication
+type AuthenticationError struct {
+	// StatusCode Status of the response rejecting the credentials, 0 when there was no response
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *AuthenticationError) Error() string {
+	message := "authentication failed"
+	if e.StatusCode != 0 {
+		message += fmt.Sprintf(", status: %d", e.StatusCode)
+	}
+	if e.Message != "" {
+		message += ", reason: " + e.Message
+	}
+	if e.Err != nil {
+		message += ", error: " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+// Is Matches ErrUnauthorized when the server rejected the credentials
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrUnauthorized && isAuthenticationFailure(e.StatusCode)
+}
+
+func isAuthenticationFailure(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
+
+func newAuthenticationErrorFromResponse(response *http.Response) *AuthenticationError {
+	body, err := getDecompressedBody(response)
+	return &AuthenticationError{
+		StatusCode: response.StatusCode,
+		Message:    string(body),
+		Err:        err,
+	}
+}
+
+// getTokenExpiry Decodes the expiry of a JWT, without verifying it.  Returns the zero time when the token does not expire
+func getTokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, err
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(int64(claims.Exp), 0), nil
+}
+
+// tokenFetchFunction Obtains a new token and its expiry, the zero time when it does not expire
+type tokenFetchFunction func() (string, time.Time, error)
+
+// tokenCache Keeps the token obtained from the server, refreshing it when missing or about to expire
+type tokenCache struct {
+	fetch tokenFetchFunction
+
+	mutex  sync.Mutex
+	token  *string
+	expiry time.Time
+}
+
+func newTokenCache(fetch tokenFetchFunction) *tokenCache {
+	return &tokenCache{
+		fetch: fetch,
+	}
+}
+
+func (t *tokenCache) mustRefresh() bool {
+	if t.token == nil {
+		return true
+	}
+	return !t.expiry.IsZero() && time.Now().Add(tokenRefreshMargin).After(t.expiry)
+}
+
+// get Returns the current token, refreshed when missing or about to expire.
+// The current one is kept while valid if the refresh fails
+func (t *tokenCache) get() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.mustRefresh() {
+		log.Debug("Refreshing authentication token")
+		token, expiry, err := t.fetch()
+		if err != nil {
+			log.Warning("Failed to refresh authentication token, error: ", err)
+			if t.token == nil || time.Now().After(t.expiry) {
+				return "", err
+			}
+			log.Warning("Using the current authentication token until it expires at: ", t.expiry)
+		} else {
+			t.token = &token
+			t.expiry = expiry
+		}
+	}
+	return *t.token, nil
+}
+
+// invalidate Drops the token rejected by the server, unless it was refreshed in the meantime
+func (t *tokenCache) invalidate(token string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != nil && *t.token == token {
+		t.token = nil
+	}
+}
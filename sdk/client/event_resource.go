@@ -86,11 +86,7 @@ func (a *EventResourceApiService) AddEventHandler(ctx context.Context, body mode
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -159,21 +155,7 @@ func (a *EventResourceApiService) GetEventHandlers(ctx context.Context) ([]model
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v []model.EventHandler
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -254,21 +236,7 @@ func (a *EventResourceApiService) GetEventHandlersForEvent(ctx context.Context,
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v []model.EventHandler
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -330,11 +298,7 @@ func (a *EventResourceApiService) RemoveEventHandlerStatus(ctx context.Context,
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -397,11 +361,7 @@ func (a *EventResourceApiService) UpdateEventHandler(ctx context.Context, body m
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
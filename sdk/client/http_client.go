@@ -0,0 +1,124 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+const defaultHttpTimeout = 30 * time.Second
+const defaultDialTimeout = 30 * time.Second
+const defaultKeepAlive = 30 * time.Second
+const defaultMaxIdleConns = 100
+
+// newHttpClient Builds the client used to reach the server from the settings
+func newHttpClient(httpSettings *settings.HttpSettings) (*http.Client, error) {
+	if httpSettings.HttpClient != nil {
+		return httpSettings.HttpClient, nil
+	}
+	transport := httpSettings.Transport
+	if transport == nil {
+		var err error
+		transport, err = newTransport(httpSettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	timeout := httpSettings.Timeout
+	if timeout <= 0 {
+		timeout = defaultHttpTimeout
+	}
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: nil,
+		Jar:           nil,
+		Timeout:       timeout,
+	}, nil
+}
+
+func newTransport(httpSettings *settings.HttpSettings) (*http.Transport, error) {
+	dialTimeout := httpSettings.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultDialTimeout
+	}
+	baseDialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: defaultKeepAlive,
+	}
+	transport := &http.Transport{
+		DialContext:           baseDialer.DialContext,
+		MaxIdleConns:          defaultMaxIdleConns,
+		MaxIdleConnsPerHost:   defaultMaxIdleConns,
+		IdleConnTimeout:       httpSettings.IdleConnTimeout,
+		TLSHandshakeTimeout:   httpSettings.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: httpSettings.ResponseHeaderTimeout,
+		DisableCompression:    false,
+	}
+	if httpSettings.MaxIdleConns > 0 {
+		transport.MaxIdleConns = httpSettings.MaxIdleConns
+	}
+	if httpSettings.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = httpSettings.MaxIdleConnsPerHost
+	}
+	if httpSettings.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(httpSettings.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %s, reason: %s", httpSettings.ProxyUrl, err.Error())
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	tlsConfig, err := newTLSConfig(httpSettings)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// newTLSConfig Builds the TLS configuration for the custom CA bundle and client certificate, nil when none is set
+func newTLSConfig(httpSettings *settings.HttpSettings) (*tls.Config, error) {
+	if httpSettings.CACertFile == "" && httpSettings.ClientCertFile == "" && httpSettings.ClientKeyFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if httpSettings.CACertFile != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		caCerts, err := ioutil.ReadFile(httpSettings.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %s, reason: %s", httpSettings.CACertFile, err.Error())
+		}
+		if !rootCAs.AppendCertsFromPEM(caCerts) {
+			return nil, fmt.Errorf("no certificates found in CA bundle: %s", httpSettings.CACertFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if httpSettings.ClientCertFile != "" || httpSettings.ClientKeyFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(httpSettings.ClientCertFile, httpSettings.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %s, reason: %s", httpSettings.ClientCertFile, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
@@ -89,11 +89,7 @@ func (a *MetadataResourceApiService) RegisterWorkflowDef(ctx context.Context, ov
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -174,21 +170,7 @@ func (a *MetadataResourceApiService) Get(ctx context.Context, name string, local
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v model.WorkflowDef
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -257,21 +239,7 @@ func (a *MetadataResourceApiService) GetAll(ctx context.Context) ([]model.Workfl
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v []model.WorkflowDef
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -342,21 +310,7 @@ func (a *MetadataResourceApiService) GetTaskDef(ctx context.Context, tasktype st
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v model.TaskDef
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -425,21 +379,7 @@ func (a *MetadataResourceApiService) GetTaskDefs(ctx context.Context) ([]model.T
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		if localVarHttpResponse.StatusCode == 200 {
-			var v []model.TaskDef
-			err = a.decode(&v, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHttpResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHttpResponse, newErr
-		}
-		return localVarReturnValue, localVarHttpResponse, newErr
+		return localVarReturnValue, localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarReturnValue, localVarHttpResponse, nil
@@ -502,11 +442,7 @@ func (a *MetadataResourceApiService) UpdateTaskDef(ctx context.Context, body mod
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -569,11 +505,7 @@ func (a *MetadataResourceApiService) RegisterTaskDef(ctx context.Context, body [
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -635,11 +567,7 @@ func (a *MetadataResourceApiService) UnregisterTaskDef(ctx context.Context, task
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -703,11 +631,7 @@ func (a *MetadataResourceApiService) UnregisterWorkflowDef(ctx context.Context,
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -770,11 +694,7 @@ func (a *MetadataResourceApiService) Update(ctx context.Context, body []model.Wo
 	}
 
 	if localVarHttpResponse.StatusCode >= 300 {
-		newErr := GenericSwaggerError{
-			body:  localVarBody,
-			error: string(localVarBody),
-		}
-		return localVarHttpResponse, newErr
+		return localVarHttpResponse, newAPIError(localVarHttpResponse, localVarBody)
 	}
 
 	return localVarHttpResponse, nil
@@ -0,0 +1,130 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry Whether the outcome of an attempt is worth retrying with the policy
+func shouldRetry(policy *settings.RetryPolicy, request *http.Request, response *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+	if !policy.RetryNonIdempotent && !isIdempotent(request.Method) {
+		return false
+	}
+	var authenticationError *AuthenticationError
+	if errors.As(err, &authenticationError) {
+		return false
+	}
+	if response == nil {
+		return err != nil
+	}
+	for _, statusCode := range policy.RetryableStatusCodes {
+		if response.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// getRetryDelay Exponential backoff with jitter before the next attempt, replaced by the Retry-After of the response when present
+func getRetryDelay(policy *settings.RetryPolicy, attempt int, response *http.Response) time.Duration {
+	if retryAfter, ok := getRetryAfter(response); ok {
+		if policy.MaxDelay > 0 && retryAfter > policy.MaxDelay {
+			return policy.MaxDelay
+		}
+		return retryAfter
+	}
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(policy.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+	if policy.Jitter > 0 {
+		delay += delay * policy.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// getRetryAfter Parses the Retry-After header, either in seconds or as an http date
+func getRetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	delay := time.Until(date)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// copyRequest Copies the request to send it again, with a fresh body
+func copyRequest(request *http.Request) (*http.Request, error) {
+	requestCopy := request.Clone(request.Context())
+	if request.Body != nil && request.Body != http.NoBody {
+		if request.GetBody == nil {
+			return nil, errors.New("unable to resend the request body")
+		}
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		requestCopy.Body = body
+	}
+	return requestCopy, nil
+}
+
+// discardResponse Releases the connection of a response that is not returned to the caller
+func discardResponse(response *http.Response) {
+	if response == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+}
@@ -19,6 +19,21 @@ var counterTemplates = map[MetricName]*MetricDetails{
 	TASK_POLL: NewMetricDetails(
 		TASK_POLL,
 		TASK_POLL_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+			DOMAIN,
+		},
+	),
+	TASK_EXECUTION_ABANDONED: NewMetricDetails(
+		TASK_EXECUTION_ABANDONED,
+		TASK_EXECUTION_ABANDONED_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+		},
+	),
+	TASK_EXECUTION_EXPIRED: NewMetricDetails(
+		TASK_EXECUTION_EXPIRED,
+		TASK_EXECUTION_EXPIRED_DOC,
 		[]MetricLabel{
 			TASK_TYPE,
 		},
@@ -42,6 +57,7 @@ var counterTemplates = map[MetricName]*MetricDetails{
 		TASK_POLL_ERROR_DOC,
 		[]MetricLabel{
 			TASK_TYPE,
+			DOMAIN,
 			EXCEPTION,
 		},
 	),
@@ -57,10 +73,17 @@ var counterTemplates = map[MetricName]*MetricDetails{
 		TASK_EXECUTE_ERROR_DOC,
 		[]MetricLabel{
 			TASK_TYPE,
+			DOMAIN,
 			EXCEPTION,
 		},
 	),
-	
+	TASK_EXECUTE_PANIC: NewMetricDetails(
+		TASK_EXECUTE_PANIC,
+		TASK_EXECUTE_PANIC_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+		},
+	),
 	TASK_UPDATE_ERROR: NewMetricDetails(
 		TASK_UPDATE_ERROR,
 		TASK_UPDATE_ERROR_DOC,
@@ -96,8 +119,31 @@ func init() {
 }
 
 func IncrementTaskPoll(taskType string) {
+	IncrementTaskPollWithDomain(taskType, "")
+}
+
+func IncrementTaskPollWithDomain(taskType string, domain string) {
 	incrementCounter(
 		TASK_POLL,
+		[]string{
+			taskType,
+			domain,
+		},
+	)
+}
+
+func IncrementTaskExecutionAbandoned(taskType string) {
+	incrementCounter(
+		TASK_EXECUTION_ABANDONED,
+		[]string{
+			taskType,
+		},
+	)
+}
+
+func IncrementTaskExecutionExpired(taskType string) {
+	incrementCounter(
+		TASK_EXECUTION_EXPIRED,
 		[]string{
 			taskType,
 		},
@@ -123,10 +169,15 @@ func IncrementUncaughtException(message string) {
 }
 
 func IncrementTaskPollError(taskType string, err error) {
+	IncrementTaskPollErrorWithDomain(taskType, "", err)
+}
+
+func IncrementTaskPollErrorWithDomain(taskType string, domain string, err error) {
 	incrementCounter(
 		TASK_POLL_ERROR,
 		[]string{
 			taskType,
+			domain,
 			err.Error(),
 		},
 	)
@@ -142,15 +193,29 @@ func IncrementTaskPaused(taskType string) {
 }
 
 func IncrementTaskExecuteError(taskType string, err error) {
+	IncrementTaskExecuteErrorWithDomain(taskType, "", err)
+}
+
+func IncrementTaskExecuteErrorWithDomain(taskType string, domain string, err error) {
 	incrementCounter(
 		TASK_EXECUTE_ERROR,
 		[]string{
 			taskType,
+			domain,
 			err.Error(),
 		},
 	)
 }
 
+func IncrementTaskExecutePanic(taskType string) {
+	incrementCounter(
+		TASK_EXECUTE_PANIC,
+		[]string{
+			taskType,
+		},
+	)
+}
+
 func IncrementTaskUpdateError(taskType string, err error) {
 	incrementCounter(
 		TASK_UPDATE_ERROR,
@@ -16,6 +16,11 @@ import (
 var gaugeByName = map[MetricName]*prometheus.GaugeVec{}
 
 var gaugeTemplates = map[MetricName]*MetricDetails{
+	CIRCUIT_BREAKER_STATE: NewMetricDetails(
+		CIRCUIT_BREAKER_STATE,
+		CIRCUIT_BREAKER_STATE_DOC,
+		[]MetricLabel{},
+	),
 	WORKFLOW_INPUT_SIZE: NewMetricDetails(
 		WORKFLOW_INPUT_SIZE,
 		WORKFLOW_INPUT_SIZE_DOC,
@@ -31,11 +36,35 @@ var gaugeTemplates = map[MetricName]*MetricDetails{
 			TASK_TYPE,
 		},
 	),
+	TASK_RESULT_OUTBOX_SIZE: NewMetricDetails(
+		TASK_RESULT_OUTBOX_SIZE,
+		TASK_RESULT_OUTBOX_SIZE_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+		},
+	),
 	TASK_POLL_TIME: NewMetricDetails(
 		TASK_POLL_TIME,
 		TASK_POLL_TIME_DOC,
 		[]MetricLabel{
 			TASK_TYPE,
+			DOMAIN,
+		},
+	),
+	TASK_QUEUE_SIZE: NewMetricDetails(
+		TASK_QUEUE_SIZE,
+		TASK_QUEUE_SIZE_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+			DOMAIN,
+		},
+	),
+	TASK_WORKER_CONCURRENCY: NewMetricDetails(
+		TASK_WORKER_CONCURRENCY,
+		TASK_WORKER_CONCURRENCY_DOC,
+		[]MetricLabel{
+			TASK_TYPE,
+			DOMAIN,
 		},
 	),
 	TASK_EXECUTE_TIME: NewMetricDetails(
@@ -43,6 +72,7 @@ var gaugeTemplates = map[MetricName]*MetricDetails{
 		TASK_EXECUTE_TIME_DOC,
 		[]MetricLabel{
 			TASK_TYPE,
+			DOMAIN,
 		},
 	),
 	TASK_UPDATE_TIME: NewMetricDetails(
@@ -82,11 +112,26 @@ func RecordTaskResultPayloadSize(taskType string, payloadSize float64) {
 	)
 }
 
+func RecordTaskResultOutboxSize(taskType string, size float64) {
+	setGauge(
+		TASK_RESULT_OUTBOX_SIZE,
+		[]string{
+			taskType,
+		},
+		size,
+	)
+}
+
 func RecordTaskPollTime(taskType string, timeSpent float64) {
+	RecordTaskPollTimeWithDomain(taskType, "", timeSpent)
+}
+
+func RecordTaskPollTimeWithDomain(taskType string, domain string, timeSpent float64) {
 	setGauge(
 		TASK_POLL_TIME,
 		[]string{
 			taskType,
+			domain,
 		},
 		timeSpent,
 	)
@@ -102,16 +147,51 @@ func RecordTaskUpdateTime(taskType string, timeSpent float64) {
 	)
 }
 
+func RecordTaskQueueSize(taskType string, domain string, queueSize float64) {
+	setGauge(
+		TASK_QUEUE_SIZE,
+		[]string{
+			taskType,
+			domain,
+		},
+		queueSize,
+	)
+}
+
+func RecordTaskWorkerConcurrency(taskType string, domain string, concurrency float64) {
+	setGauge(
+		TASK_WORKER_CONCURRENCY,
+		[]string{
+			taskType,
+			domain,
+		},
+		concurrency,
+	)
+}
+
 func RecordTaskExecuteTime(taskType string, timeSpent float64) {
+	RecordTaskExecuteTimeWithDomain(taskType, "", timeSpent)
+}
+
+func RecordTaskExecuteTimeWithDomain(taskType string, domain string, timeSpent float64) {
 	setGauge(
 		TASK_EXECUTE_TIME,
 		[]string{
 			taskType,
+			domain,
 		},
 		timeSpent,
 	)
 }
 
+func RecordCircuitBreakerState(state float64) {
+	setGauge(
+		CIRCUIT_BREAKER_STATE,
+		[]string{},
+		state,
+	)
+}
+
 func newGauge(metricDetails *MetricDetails) *prometheus.GaugeVec {
 	return prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -12,6 +12,7 @@ package metrics
 type MetricLabel string
 
 const (
+	DOMAIN           MetricLabel = "domain"
 	ENTITY_NAME      MetricLabel = "entityName"
 	EXCEPTION        MetricLabel = "exception"
 	OPERATION        MetricLabel = "operation"
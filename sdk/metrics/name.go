@@ -13,17 +13,24 @@ type MetricName string
 
 //List of metrics that are collected when metrics server is enabled
 const (
+	CIRCUIT_BREAKER_STATE     MetricName = "circuit_breaker_state"
 	EXTERNAL_PAYLOAD_USED     MetricName = "external_payload_used"
 	TASK_EXECUTE_ERROR        MetricName = "task_execute_error"
+	TASK_EXECUTE_PANIC        MetricName = "task_execute_panic"
 	TASK_EXECUTE_TIME         MetricName = "task_execute_time"
+	TASK_EXECUTION_ABANDONED  MetricName = "task_execution_abandoned"
+	TASK_EXECUTION_EXPIRED    MetricName = "task_execution_expired"
 	TASK_EXECUTION_QUEUE_FULL MetricName = "task_execution_queue_full"
 	TASK_PAUSED               MetricName = "task_paused"
 	TASK_POLL                 MetricName = "task_poll"
 	TASK_POLL_ERROR           MetricName = "task_poll_error"
 	TASK_POLL_TIME            MetricName = "task_poll_time"
+	TASK_QUEUE_SIZE           MetricName = "task_queue_size"
+	TASK_RESULT_OUTBOX_SIZE   MetricName = "task_result_outbox_size"
 	TASK_RESULT_SIZE          MetricName = "task_result_size"
 	TASK_UPDATE_ERROR         MetricName = "task_update_error"
 	TASK_UPDATE_TIME          MetricName = "task_update_time"
+	TASK_WORKER_CONCURRENCY   MetricName = "task_worker_concurrency"
 	THREAD_UNCAUGHT_EXCEPTION MetricName = "thread_uncaught_exceptions"
 	WORKFLOW_INPUT_SIZE       MetricName = "workflow_input_size"
 	WORKFLOW_START_ERROR      MetricName = "workflow_start_error"
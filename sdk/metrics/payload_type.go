@@ -13,5 +13,5 @@ type PayloadType string
 
 const (
 	TASK_INPUT  PayloadType = "TASK_INPUT"
-	TASK_OUTPUT PayloadType = "TASK_INPUT"
+	TASK_OUTPUT PayloadType = "TASK_OUTPUT"
 )
@@ -0,0 +1,27 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package model
+
+// ErrorResponse Body of the error responses of the Conductor server
+type ErrorResponse struct {
+	Status           int                    `json:"status,omitempty"`
+	Code             string                 `json:"code,omitempty"`
+	Message          string                 `json:"message,omitempty"`
+	Instance         string                 `json:"instance,omitempty"`
+	Retryable        bool                   `json:"retryable,omitempty"`
+	ValidationErrors []ValidationError      `json:"validationErrors,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type ValidationError struct {
+	Path         string `json:"path,omitempty"`
+	Message      string `json:"message,omitempty"`
+	InvalidValue string `json:"invalidValue,omitempty"`
+}
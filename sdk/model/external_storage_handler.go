@@ -9,4 +9,8 @@
 
 package model
 
+// ExternalStorageHandler Stores a payload in the external storage, returning the path where it can be found
 type ExternalStorageHandler func(data map[string]interface{}) (string, error)
+
+// ExternalStorageDownloader Reads a payload from the uri of an external storage location
+type ExternalStorageDownloader func(uri string) (map[string]interface{}, error)
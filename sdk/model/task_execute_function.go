@@ -10,6 +10,7 @@
 package model
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 
@@ -18,17 +19,33 @@ import (
 
 var hostname, _ = os.Hostname()
 
+// GetHostname Returns the hostname of the machine, the worker id of the task results built without one
+func GetHostname() string {
+	return hostname
+}
+
 type ExecuteTaskFunction func(t *Task) (interface{}, error)
 
+// ExecuteTaskFunctionWithContext Task execution function receiving a context that is done when the execution
+// deadline is reached or the worker is shut down
+type ExecuteTaskFunctionWithContext func(ctx context.Context, t *Task) (interface{}, error)
+
+// NewExecuteTaskFunctionWithContext Adapts an ExecuteTaskFunction that does not take a context
+func NewExecuteTaskFunctionWithContext(executeFunction ExecuteTaskFunction) ExecuteTaskFunctionWithContext {
+	return func(ctx context.Context, t *Task) (interface{}, error) {
+		return executeFunction(t)
+	}
+}
+
 type ValidateWorkflowFunction func(w *Workflow) (bool, error)
 
+// NewTaskResultFromTask Result of the task, reported by the worker id the task was polled with, or the hostname when it has none
 func NewTaskResultFromTask(task *Task) *TaskResult {
-	return &TaskResult{
-		TaskId:             task.TaskId,
-		WorkflowInstanceId: task.WorkflowInstanceId,
-		WorkerId:           hostname,
+	workerId := task.WorkerId
+	if workerId == "" {
+		workerId = hostname
 	}
-
+	return NewTaskResultWithWorkerId(task.TaskId, task.WorkflowInstanceId, workerId)
 }
 
 func NewTaskResultFromTaskWithError(t *Task, err error) *TaskResult {
@@ -38,13 +55,17 @@ func NewTaskResultFromTaskWithError(t *Task, err error) *TaskResult {
 	return taskResult
 }
 
+// NewTaskResult Result of the task, reported by the hostname
 func NewTaskResult(taskId string, workflowInstanceId string) *TaskResult {
+	return NewTaskResultWithWorkerId(taskId, workflowInstanceId, hostname)
+}
+
+func NewTaskResultWithWorkerId(taskId string, workflowInstanceId string, workerId string) *TaskResult {
 	return &TaskResult{
 		TaskId:             taskId,
 		WorkflowInstanceId: workflowInstanceId,
-		WorkerId:           hostname,
+		WorkerId:           workerId,
 	}
-
 }
 
 func GetTaskResultFromTaskExecutionOutput(t *Task, taskExecutionOutput interface{}) (*TaskResult, error) {
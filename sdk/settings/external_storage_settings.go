@@ -13,10 +13,15 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 )
 
+// ExternalStorageSettings configures the external payload storage for the task workers.
+// Task outputs above TaskOutputPayloadThresholdKB are stored with the ExternalStorageHandler, and tasks with
+// outputs above TaskOutputMaxPayloadThresholdKB are failed.  Zero disables the respective threshold.
+// Task inputs stored externally are read with the ExternalStorageDownloader, when set
 type ExternalStorageSettings struct {
 	TaskOutputPayloadThresholdKB    int64
 	TaskOutputMaxPayloadThresholdKB int64
 	ExternalStorageHandler          model.ExternalStorageHandler
+	ExternalStorageDownloader       model.ExternalStorageDownloader
 }
 
 func NewExternalStorageSettings(
@@ -9,9 +9,43 @@
 
 package settings
 
+import (
+	"net/http"
+	"time"
+)
+
+// HttpSettings Connection to the Conductor server.  Zero values use the defaults
 type HttpSettings struct {
 	BaseUrl string
 	Headers map[string]string
+	// Timeout Time limit of each request, including reading the response.  Defaults to 30 seconds
+	Timeout time.Duration
+	// DialTimeout Time limit to establish a connection.  Defaults to 30 seconds
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout Time limit of the TLS handshake.  No limit by default
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout Time limit to receive the response headers once the request is sent.  No limit by default
+	ResponseHeaderTimeout time.Duration
+	// IdleConnTimeout Time an idle connection is kept open.  No limit by default
+	IdleConnTimeout time.Duration
+	// MaxIdleConns Idle connections kept open across all hosts.  Defaults to 100
+	MaxIdleConns int
+	// MaxIdleConnsPerHost Idle connections kept open to the server.  Defaults to 100
+	MaxIdleConnsPerHost int
+	// ProxyUrl Proxy for every request, e.g. http://proxy:3128.  Connects directly by default
+	ProxyUrl string
+	// CACertFile PEM bundle of the certificate authorities trusted in addition to the system ones
+	CACertFile string
+	// ClientCertFile PEM certificate presented to the server for mutual TLS, along with ClientKeyFile
+	ClientCertFile string
+	// ClientKeyFile PEM private key of ClientCertFile
+	ClientKeyFile string
+	// Transport Used instead of the transport built from the settings above, keeping Timeout
+	Transport http.RoundTripper
+	// RetryPolicy Retries of the failed requests.  Defaults to NewDefaultRetryPolicy
+	RetryPolicy *RetryPolicy
+	// HttpClient Used as is instead of building a client, ignoring every setting but BaseUrl and Headers
+	HttpClient *http.Client
 }
 
 func NewHttpDefaultSettings() *HttpSettings {
@@ -0,0 +1,56 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package settings
+
+import (
+	"net/http"
+	"time"
+)
+
+//RetryPolicy configures how the API client retries requests failing with a transport error or a retryable status
+type RetryPolicy struct {
+	// MaxAttempts Attempts of each request, including the first one.  1 disables retries
+	MaxAttempts int
+	// InitialDelay Wait before the first retry, multiplied by Multiplier on every following one
+	InitialDelay time.Duration
+	Multiplier   float64
+	// MaxDelay Longest wait between attempts, also capping the Retry-After header of the server
+	MaxDelay time.Duration
+	// Jitter Fraction of the delay randomly added or removed, in the range [0, 1]
+	Jitter float64
+	// RetryableStatusCodes Response statuses worth retrying
+	RetryableStatusCodes []int
+	// RetryNonIdempotent Retries POST and PATCH requests as well, which may be applied twice by the server
+	RetryNonIdempotent bool
+}
+
+//NewDefaultRetryPolicy retries idempotent requests up to 3 attempts, waiting from 100ms up to 10 seconds
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   2,
+		MaxDelay:     10 * time.Second,
+		Jitter:       0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+//NewNoRetryPolicy sends every request once
+func NewNoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 1,
+	}
+}
@@ -0,0 +1,97 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+const fileUriScheme = "file://"
+
+//LocalFileSystemStorage Reference external payload storage, keeping each payload as a JSON file in a directory.
+//Meant for local development, where the workers and the Conductor server share the file system
+type LocalFileSystemStorage struct {
+	directory string
+}
+
+//NewLocalFileSystemStorage Creates the storage, along with its directory if it does not exist
+func NewLocalFileSystemStorage(directory string) (*LocalFileSystemStorage, error) {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+	absoluteDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalFileSystemStorage{
+		directory: absoluteDirectory,
+	}, nil
+}
+
+//NewExternalStorageSettings External storage settings backed by this storage
+func (s *LocalFileSystemStorage) NewExternalStorageSettings(taskOutputPayloadThresholdKB int64, taskOutputMaxPayloadThresholdKB int64) *settings.ExternalStorageSettings {
+	externalStorageSettings := settings.NewExternalStorageSettings(
+		taskOutputPayloadThresholdKB,
+		taskOutputMaxPayloadThresholdKB,
+		s.Upload,
+	)
+	externalStorageSettings.ExternalStorageDownloader = s.Download
+	return externalStorageSettings
+}
+
+//Upload Writes the payload to a new file, returning its path.  Compatible with model.ExternalStorageHandler
+func (s *LocalFileSystemStorage) Upload(data map[string]interface{}) (string, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	name, err := newPayloadName()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.directory, name)
+	err = ioutil.WriteFile(path, payload, 0644)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+//Download Reads the payload from a file path or file:// uri.  Compatible with model.ExternalStorageDownloader
+func (s *LocalFileSystemStorage) Download(uri string) (map[string]interface{}, error) {
+	payload, err := ioutil.ReadFile(strings.TrimPrefix(uri, fileUriScheme))
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	err = json.Unmarshal(payload, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func newPayloadName() (string, error) {
+	randomBytes := make([]byte, 16)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(randomBytes) + ".json", nil
+}
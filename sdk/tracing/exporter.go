@@ -0,0 +1,105 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SpanExporter Receives every span once ended
+type SpanExporter interface {
+	ExportSpan(span *Span)
+}
+
+var exporterMutex sync.RWMutex
+var exporter SpanExporter
+
+// SetExporter Sets the exporter for the spans of the SDK.  Spans are discarded while there is no exporter
+func SetExporter(spanExporter SpanExporter) {
+	exporterMutex.Lock()
+	defer exporterMutex.Unlock()
+	exporter = spanExporter
+}
+
+// IsEnabled Whether an exporter is set
+func IsEnabled() bool {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+	return exporter != nil
+}
+
+func exportSpan(span *Span) {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+	if exporter != nil {
+		exporter.ExportSpan(span)
+	}
+}
+
+// InMemoryExporter Keeps the exported spans in memory, for tests and local development
+type InMemoryExporter struct {
+	mutex sync.Mutex
+	spans []*Span
+}
+
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+func (e *InMemoryExporter) ExportSpan(span *Span) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+// Spans Exported spans, in the order they ended
+func (e *InMemoryExporter) Spans() []*Span {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]*Span(nil), e.spans...)
+}
+
+func (e *InMemoryExporter) Reset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.spans = nil
+}
+
+// WriterExporter Writes every exported span as a JSON line
+type WriterExporter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterExporter(writer io.Writer) *WriterExporter {
+	return &WriterExporter{
+		writer: writer,
+	}
+}
+
+func NewStdoutExporter() *WriterExporter {
+	return NewWriterExporter(os.Stdout)
+}
+
+func (e *WriterExporter) ExportSpan(span *Span) {
+	line, err := json.Marshal(span)
+	if err != nil {
+		log.Warning("Failed to export span, reason: ", err.Error())
+		return
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.writer.Write(append(line, '\n'))
+}
@@ -0,0 +1,86 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TraceParentKey Key of the trace context within headers, workflow input and task input, following W3C Trace Context
+const TraceParentKey = "traceparent"
+
+// FormatTraceParent Formats the span context as a W3C traceparent value
+func FormatTraceParent(spanContext SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-01", spanContext.TraceId, spanContext.SpanId)
+}
+
+// ParseTraceParent Parses a W3C traceparent value
+func ParseTraceParent(traceParent string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 {
+		return SpanContext{}, false
+	}
+	spanContext := SpanContext{
+		TraceId: strings.ToLower(parts[1]),
+		SpanId:  strings.ToLower(parts[2]),
+	}
+	return spanContext, spanContext.IsValid()
+}
+
+// Inject Adds the trace context of ctx to the carrier, such as the input of a workflow
+func Inject(ctx context.Context, carrier map[string]interface{}) {
+	spanContext := SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		carrier[TraceParentKey] = FormatTraceParent(spanContext)
+	}
+}
+
+// Extract Returns a copy of ctx carrying the trace context found in the carrier, such as the input of a task
+func Extract(ctx context.Context, carrier map[string]interface{}) context.Context {
+	traceParent, ok := carrier[TraceParentKey].(string)
+	if !ok {
+		return ctx
+	}
+	spanContext, ok := ParseTraceParent(traceParent)
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, spanContext)
+}
+
+// InjectIntoInput Returns a copy of the workflow input with the trace context of ctx, when tracing is enabled.
+// Inputs other than maps are converted to a map through their JSON representation
+func InjectIntoInput(ctx context.Context, input interface{}) (interface{}, error) {
+	if !IsEnabled() || !SpanContextFromContext(ctx).IsValid() {
+		return input, nil
+	}
+	carrier := make(map[string]interface{})
+	switch value := input.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, entry := range value {
+			carrier[key] = entry
+		}
+	default:
+		data, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, &carrier)
+		if err != nil {
+			return nil, fmt.Errorf("workflow input must be a JSON object to carry the trace context, reason: %s", err.Error())
+		}
+	}
+	Inject(ctx, carrier)
+	return carrier, nil
+}
@@ -0,0 +1,103 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type spanContextKey struct{}
+
+// SpanContext Identifies a span within a trace, propagated across processes
+type SpanContext struct {
+	TraceId string
+	SpanId  string
+}
+
+func (sc SpanContext) IsValid() bool {
+	return len(sc.TraceId) == 32 && len(sc.SpanId) == 16
+}
+
+// Span Timed operation within a trace.  Spans are exported once ended
+type Span struct {
+	Name         string            `json:"name"`
+	TraceId      string            `json:"traceId"`
+	SpanId       string            `json:"spanId"`
+	ParentSpanId string            `json:"parentSpanId,omitempty"`
+	StartTime    time.Time         `json:"startTime"`
+	EndTime      time.Time         `json:"endTime"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// StartSpan Starts a span, child of the span or remote span context within ctx if any.
+// Returns a copy of ctx carrying the new span
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	span := &Span{
+		Name:       name,
+		SpanId:     newId(8),
+		StartTime:  time.Now(),
+		Attributes: make(map[string]string),
+	}
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() {
+		span.TraceId = parent.TraceId
+		span.ParentSpanId = parent.SpanId
+	} else {
+		span.TraceId = newId(16)
+	}
+	return ContextWithSpanContext(ctx, span.SpanContext()), span
+}
+
+func (s *Span) SpanContext() SpanContext {
+	return SpanContext{
+		TraceId: s.TraceId,
+		SpanId:  s.SpanId,
+	}
+}
+
+func (s *Span) SetAttribute(key string, value string) {
+	s.Attributes[key] = value
+}
+
+// End Finishes the span, recording err as failure if not nil, and sends it to the exporter
+func (s *Span) End(err error) {
+	s.EndTime = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	exportSpan(s)
+}
+
+// ContextWithSpanContext Returns a copy of ctx carrying the span context, used as parent by the next spans
+func ContextWithSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext)
+}
+
+// SpanContextFromContext Span context carried by ctx, invalid when there is none
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	spanContext, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return spanContext
+}
+
+func newId(size int) string {
+	id := make([]byte, size)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,162 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// adminWorkerState Worker state returned by the admin endpoints
+type adminWorkerState struct {
+	Name            string       `json:"name"`
+	TaskName        string       `json:"taskName"`
+	Domain          string       `json:"domain,omitempty"`
+	Identity        string       `json:"identity,omitempty"`
+	BatchSize       int          `json:"batchSize"`
+	RunningTasks    int          `json:"runningTasks"`
+	PollIntervalMs  int64        `json:"pollIntervalMs"`
+	Paused          bool         `json:"paused"`
+	LastPollError   *WorkerError `json:"lastPollError,omitempty"`
+	LastUpdateError *WorkerError `json:"lastUpdateError,omitempty"`
+}
+
+type adminBatchSizeRequest struct {
+	BatchSize int `json:"batchSize"`
+}
+
+type adminPollIntervalRequest struct {
+	PollIntervalMs int64 `json:"pollIntervalMs"`
+}
+
+type adminErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// MountAdminHandler Registers the worker admin endpoints under pathPrefix on the mux.
+// Use http.DefaultServeMux to share the port with metrics.ProvideMetrics.  Workers are addressed by their WorkerName
+//   - GET  {pathPrefix}/workers                     State of every worker
+//   - GET  {pathPrefix}/workers/{name}              State of a single worker
+//   - POST {pathPrefix}/workers/{name}/batchSize    Body {"batchSize": 5}
+//   - POST {pathPrefix}/workers/{name}/pollInterval Body {"pollIntervalMs": 100}
+//   - POST {pathPrefix}/workers/{name}/pause
+//   - POST {pathPrefix}/workers/{name}/resume
+func (c *TaskRunner) MountAdminHandler(mux *http.ServeMux, pathPrefix string) {
+	workersPath := strings.TrimSuffix(pathPrefix, "/") + "/workers"
+	mux.HandleFunc(workersPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeAdminError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+			return
+		}
+		workers := c.ListWorkers()
+		states := make([]adminWorkerState, len(workers))
+		for i := range workers {
+			states[i] = newAdminWorkerState(&workers[i])
+		}
+		writeAdminResponse(w, http.StatusOK, states)
+	})
+	mux.HandleFunc(workersPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, workersPath+"/"), "/")
+		taskName := parts[0]
+		if _, ok := c.workerRegistry.get(taskName); !ok {
+			writeAdminError(w, http.StatusNotFound, fmt.Errorf("no worker registered for taskName: %s", taskName))
+			return
+		}
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			c.writeAdminWorkerState(w, taskName)
+		case len(parts) == 2 && r.Method == http.MethodPost:
+			c.handleAdminAction(w, r, taskName, parts[1])
+		case len(parts) <= 2:
+			writeAdminError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+		default:
+			writeAdminError(w, http.StatusNotFound, fmt.Errorf("not found: %s", r.URL.Path))
+		}
+	})
+}
+
+func (c *TaskRunner) handleAdminAction(w http.ResponseWriter, r *http.Request, taskName string, action string) {
+	var err error
+	switch action {
+	case "batchSize":
+		var request adminBatchSizeRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err == nil && request.BatchSize < 1 {
+			err = fmt.Errorf("batchSize must be positive, pause the worker to stop polling")
+		}
+		if err == nil {
+			err = c.SetBatchSize(taskName, request.BatchSize)
+		}
+	case "pollInterval":
+		var request adminPollIntervalRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err == nil && request.PollIntervalMs < 0 {
+			err = fmt.Errorf("pollIntervalMs can not be negative")
+		}
+		if err == nil {
+			err = c.SetPollIntervalForTask(taskName, time.Duration(request.PollIntervalMs)*time.Millisecond)
+		}
+	case "pause":
+		err = c.PauseWorker(taskName)
+	case "resume":
+		err = c.ResumeWorker(taskName)
+	default:
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("unknown action: %s", action))
+		return
+	}
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	log.Info("Applied admin action: ", action, ", to worker for task: ", taskName)
+	c.writeAdminWorkerState(w, taskName)
+}
+
+func (c *TaskRunner) writeAdminWorkerState(w http.ResponseWriter, taskName string) {
+	description, err := c.DescribeWorker(taskName)
+	if err != nil {
+		writeAdminError(w, http.StatusNotFound, err)
+		return
+	}
+	writeAdminResponse(w, http.StatusOK, newAdminWorkerState(description))
+}
+
+func newAdminWorkerState(description *WorkerDescription) adminWorkerState {
+	return adminWorkerState{
+		Name:            description.Name,
+		TaskName:        description.TaskName,
+		Domain:          description.Domain,
+		Identity:        description.Identity,
+		BatchSize:       description.BatchSize,
+		RunningTasks:    description.RunningTasks,
+		PollIntervalMs:  description.PollInterval.Milliseconds(),
+		Paused:          description.Paused,
+		LastPollError:   description.LastPollError,
+		LastUpdateError: description.LastUpdateError,
+	}
+}
+
+func writeAdminResponse(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Warning("Failed to write admin response, reason: ", err.Error())
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, statusCode int, err error) {
+	writeAdminResponse(w, statusCode, adminErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,234 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+
+	"github.com/antihax/optional"
+	log "github.com/sirupsen/logrus"
+)
+
+// AutoscalingSample Observation of a worker used by the autoscaling policy to choose its batch size
+type AutoscalingSample struct {
+	TaskName string
+	Domains  []string
+	// QueueSize Tasks queued in all the domains polled by the worker
+	QueueSize    int
+	BatchSize    int
+	RunningTasks int
+	// AverageExecuteTime Moving average of the recent executions, 0 when there were none yet
+	AverageExecuteTime time.Duration
+	MinBatchSize       int
+	MaxBatchSize       int
+}
+
+// AutoscalingPolicy Chooses the batch size of a worker.  The result is clamped to the bounds of the worker
+type AutoscalingPolicy interface {
+	BatchSize(sample AutoscalingSample) int
+}
+
+// AutoscalingPolicyFunc Adapts a function into an AutoscalingPolicy
+type AutoscalingPolicyFunc func(sample AutoscalingSample) int
+
+func (f AutoscalingPolicyFunc) BatchSize(sample AutoscalingSample) int {
+	return f(sample)
+}
+
+// QueueDrainPolicy Chooses the concurrency needed to drain the queued tasks within DrainTime, given the average execute time.
+// Uses one worker per queued task while the execute time is unknown
+type QueueDrainPolicy struct {
+	DrainTime time.Duration
+}
+
+func NewQueueDrainPolicy(drainTime time.Duration) *QueueDrainPolicy {
+	return &QueueDrainPolicy{
+		DrainTime: drainTime,
+	}
+}
+
+func (p *QueueDrainPolicy) BatchSize(sample AutoscalingSample) int {
+	pending := sample.QueueSize + sample.RunningTasks
+	if sample.AverageExecuteTime <= 0 || p.DrainTime <= 0 {
+		return pending
+	}
+	return int(math.Ceil(float64(pending) * float64(sample.AverageExecuteTime) / float64(p.DrainTime)))
+}
+
+type autoscalingBounds struct {
+	minBatchSize int
+	maxBatchSize int
+}
+
+// Autoscaler Adjusts the batch size of the workers of a TaskRunner according to their queue size and execute time
+type Autoscaler struct {
+	taskRunner   *TaskRunner
+	policy       AutoscalingPolicy
+	taskResource *client.TaskResourceApiService
+
+	boundsByWorkerNameMutex sync.RWMutex
+	boundsByWorkerName      map[string]autoscalingBounds
+}
+
+func NewAutoscaler(taskRunner *TaskRunner, policy AutoscalingPolicy) *Autoscaler {
+	return &Autoscaler{
+		taskRunner:         taskRunner,
+		policy:             policy,
+		taskResource:       taskRunner.conductorTaskResourceClient,
+		boundsByWorkerName: make(map[string]autoscalingBounds),
+	}
+}
+
+// SetBounds Enables autoscaling for the worker, keeping its batch size between minBatchSize and maxBatchSize.
+// Workers polling domains are addressed by their WorkerName
+func (a *Autoscaler) SetBounds(workerName string, minBatchSize int, maxBatchSize int) error {
+	if minBatchSize < 1 || maxBatchSize < minBatchSize {
+		return fmt.Errorf("batch size bounds must satisfy 1 <= min <= max, got: [%d, %d]", minBatchSize, maxBatchSize)
+	}
+	a.boundsByWorkerNameMutex.Lock()
+	defer a.boundsByWorkerNameMutex.Unlock()
+	a.boundsByWorkerName[workerName] = autoscalingBounds{
+		minBatchSize: minBatchSize,
+		maxBatchSize: maxBatchSize,
+	}
+	return nil
+}
+
+// Start Scales the workers every interval, until the context is done or the TaskRunner shuts down
+func (a *Autoscaler) Start(ctx context.Context, interval time.Duration) {
+	go a.scaleDaemon(ctx, interval)
+}
+
+// Scale Samples the queue size of every autoscaled worker and applies the batch size chosen by the policy
+func (a *Autoscaler) Scale(ctx context.Context) error {
+	var scaleError error
+	for _, workerName := range a.getWorkerNames() {
+		err := a.scaleWorker(ctx, workerName)
+		if err != nil {
+			log.Warning("Failed to autoscale worker: ", workerName, ", reason: ", err.Error())
+			scaleError = err
+		}
+	}
+	return scaleError
+}
+
+func (a *Autoscaler) scaleDaemon(ctx context.Context, interval time.Duration) {
+	defer concurrency.HandlePanicError("autoscaler")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.taskRunner.runnerContext.Done():
+			return
+		case <-ticker.C:
+			a.Scale(ctx)
+		}
+	}
+}
+
+func (a *Autoscaler) scaleWorker(ctx context.Context, workerName string) error {
+	bounds, ok := a.getBounds(workerName)
+	if !ok {
+		return fmt.Errorf("no autoscaling bounds for worker: %s", workerName)
+	}
+	w, ok := a.taskRunner.workerRegistry.get(workerName)
+	if !ok || !a.taskRunner.isWorkerRegistered(workerName) {
+		return fmt.Errorf("no worker registered with name: %s", workerName)
+	}
+	taskName := w.TaskName()
+	domains := getDomains(w)
+	queueSize := 0
+	for _, domain := range domains {
+		domainQueueSize, err := a.getQueueSize(ctx, taskName, domain)
+		if err != nil {
+			return err
+		}
+		metrics.RecordTaskQueueSize(taskName, domain, float64(domainQueueSize))
+		queueSize += domainQueueSize
+	}
+	runningTasks, err := a.taskRunner.getRunningWorkers(workerName)
+	if err != nil {
+		return err
+	}
+	sample := AutoscalingSample{
+		TaskName:           taskName,
+		Domains:            domains,
+		QueueSize:          queueSize,
+		BatchSize:          a.taskRunner.GetBatchSizeForTask(workerName),
+		RunningTasks:       runningTasks,
+		AverageExecuteTime: a.taskRunner.getAverageExecuteTime(taskName),
+		MinBatchSize:       bounds.minBatchSize,
+		MaxBatchSize:       bounds.maxBatchSize,
+	}
+	batchSize := a.policy.BatchSize(sample)
+	if batchSize < bounds.minBatchSize {
+		batchSize = bounds.minBatchSize
+	}
+	if batchSize > bounds.maxBatchSize {
+		batchSize = bounds.maxBatchSize
+	}
+	metrics.RecordTaskWorkerConcurrency(taskName, strings.Join(domains, ","), float64(batchSize))
+	if batchSize == sample.BatchSize {
+		return nil
+	}
+	log.Debug(
+		"Autoscaling worker: ", workerName,
+		", queueSize: ", queueSize,
+		", averageExecuteTime: ", sample.AverageExecuteTime,
+		", from: ", sample.BatchSize,
+		", to: ", batchSize,
+	)
+	return a.taskRunner.SetBatchSize(workerName, batchSize)
+}
+
+// getQueueSize Size of the queue of the task in the domain, named as in the server: domain:taskName, or taskName without domain
+func (a *Autoscaler) getQueueSize(ctx context.Context, taskName string, domain string) (int, error) {
+	queueName := WorkerName(taskName, domain)
+	sizeByQueueName, _, err := a.taskResource.Size(
+		ctx,
+		&client.TaskResourceApiSizeOpts{
+			TaskType: optional.NewInterface([]string{queueName}),
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue size, reason: %s", err.Error())
+	}
+	return int(sizeByQueueName[queueName]), nil
+}
+
+func (a *Autoscaler) getBounds(workerName string) (autoscalingBounds, bool) {
+	a.boundsByWorkerNameMutex.RLock()
+	defer a.boundsByWorkerNameMutex.RUnlock()
+	bounds, ok := a.boundsByWorkerName[workerName]
+	return bounds, ok
+}
+
+func (a *Autoscaler) getWorkerNames() []string {
+	a.boundsByWorkerNameMutex.RLock()
+	defer a.boundsByWorkerNameMutex.RUnlock()
+	workerNames := make([]string, 0, len(a.boundsByWorkerName))
+	for workerName := range a.boundsByWorkerName {
+		workerNames = append(workerNames, workerName)
+	}
+	sort.Strings(workerNames)
+	return workerNames
+}
@@ -0,0 +1,164 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const defaultPollTimeout = 100 * time.Millisecond
+const maxPollTimeout = 20 * time.Second
+const defaultEmptyPollMaxDelay = time.Second
+
+// BackoffStrategy Computes how long a worker waits before polling again.
+// The attempt starts at 1 for the first consecutive empty or failed poll, and is reset after a poll returning tasks
+type BackoffStrategy interface {
+	NextDelay(base time.Duration, attempt int) time.Duration
+}
+
+// ConstantBackoff Always waits for the base delay
+type ConstantBackoff struct{}
+
+func (b *ConstantBackoff) NextDelay(base time.Duration, attempt int) time.Duration {
+	return base
+}
+
+// ExponentialBackoff Multiplies the base delay on every consecutive attempt, up to MaxDelay or the base delay when longer.
+// Jitter is the fraction of the delay randomly added or removed, in the range [0, 1]
+type ExponentialBackoff struct {
+	Multiplier float64
+	MaxDelay   time.Duration
+	Jitter     float64
+}
+
+func NewExponentialBackoff(maxDelay time.Duration) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		Multiplier: 2,
+		MaxDelay:   maxDelay,
+		Jitter:     0.2,
+	}
+}
+
+func (b *ExponentialBackoff) NextDelay(base time.Duration, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := float64(base) * math.Pow(b.Multiplier, float64(attempt-1))
+	maxDelay := b.MaxDelay
+	if maxDelay < base {
+		maxDelay = base
+	}
+	if maxDelay > 0 && delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// pollBackoff Consecutive empty and failed polls of a single worker
+type pollBackoff struct {
+	emptyPolls  int
+	failedPolls int
+}
+
+func (b *pollBackoff) reset() {
+	b.emptyPolls = 0
+	b.failedPolls = 0
+}
+
+// SetErrorBackoffStrategy Sets the strategy used to wait after a failed poll, the base delay is 100ms.
+// Defaults to an exponential backoff with jitter, up to 10 seconds
+func (c *TaskRunner) SetErrorBackoffStrategy(strategy BackoffStrategy) error {
+	if strategy == nil {
+		return fmt.Errorf("backoff strategy can not be nil")
+	}
+	c.backoffStrategyMutex.Lock()
+	defer c.backoffStrategyMutex.Unlock()
+	c.errorBackoffStrategy = strategy
+	return nil
+}
+
+// SetEmptyPollBackoffStrategy Sets the strategy used to wait after a poll without tasks, the base delay is the poll interval.
+// Defaults to an exponential backoff with jitter, up to 1 second or the poll interval when longer.
+// Use ConstantBackoff to wait for the poll interval every time
+func (c *TaskRunner) SetEmptyPollBackoffStrategy(strategy BackoffStrategy) error {
+	if strategy == nil {
+		return fmt.Errorf("backoff strategy can not be nil")
+	}
+	c.backoffStrategyMutex.Lock()
+	defer c.backoffStrategyMutex.Unlock()
+	c.emptyPollBackoffStrategy = strategy
+	return nil
+}
+
+// SetPollTimeout Sets how long the server holds a poll request waiting for tasks before answering without them.
+// Defaults to 100ms, must not be negative and at most 20 seconds
+func (c *TaskRunner) SetPollTimeout(pollTimeout time.Duration) error {
+	if pollTimeout < 0 || pollTimeout > maxPollTimeout {
+		return fmt.Errorf("poll timeout must be in the range [0, %s], got: %s", maxPollTimeout, pollTimeout)
+	}
+	c.pollTimeoutMutex.Lock()
+	defer c.pollTimeoutMutex.Unlock()
+	c.pollTimeout = pollTimeout
+	return nil
+}
+
+func (c *TaskRunner) GetPollTimeout() time.Duration {
+	c.pollTimeoutMutex.RLock()
+	defer c.pollTimeoutMutex.RUnlock()
+	return c.pollTimeout
+}
+
+func (c *TaskRunner) getErrorBackoffDelay(attempt int) time.Duration {
+	c.backoffStrategyMutex.RLock()
+	defer c.backoffStrategyMutex.RUnlock()
+	return c.errorBackoffStrategy.NextDelay(batchPollErrorRetryInterval, attempt)
+}
+
+func (c *TaskRunner) getEmptyPollBackoffDelay(pollInterval time.Duration, attempt int) time.Duration {
+	c.backoffStrategyMutex.RLock()
+	defer c.backoffStrategyMutex.RUnlock()
+	return c.emptyPollBackoffStrategy.NextDelay(pollInterval, attempt)
+}
+
+// notifyCapacityChanged Wakes up the worker of the task waiting for available capacity, if any
+func (c *TaskRunner) notifyCapacityChanged(taskName string) {
+	select {
+	case c.getCapacityChannel(taskName) <- struct{}{}:
+	default:
+	}
+}
+
+// waitForCapacity Blocks until the running tasks, batch size or pause state of the task change, or the runner is shutting down
+func (c *TaskRunner) waitForCapacity(taskName string) {
+	select {
+	case <-c.getCapacityChannel(taskName):
+	case <-c.runnerContext.Done():
+	}
+}
+
+func (c *TaskRunner) getCapacityChannel(taskName string) chan struct{} {
+	c.capacityChannelByTaskNameMutex.Lock()
+	defer c.capacityChannelByTaskNameMutex.Unlock()
+	channel, ok := c.capacityChannelByTaskName[taskName]
+	if !ok {
+		channel = make(chan struct{}, 1)
+		c.capacityChannelByTaskName[taskName] = channel
+	}
+	return channel
+}
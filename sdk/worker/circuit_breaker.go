@@ -0,0 +1,204 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultCircuitBreakerFailureThreshold = 5
+const defaultCircuitBreakerProbeInterval = time.Second
+
+// CircuitBreakerState State of the circuit breaker shared by all the workers of a TaskRunner
+type CircuitBreakerState int
+
+const (
+	// CircuitClosed The server is reachable and the workers poll as usual
+	CircuitClosed CircuitBreakerState = iota
+	// CircuitOpen The server is failing, polling is suspended for every task while its health is probed
+	CircuitOpen
+	// CircuitHalfOpen The server reported healthy again, a single poll probes it while the other workers keep waiting.
+	// The next request closes the circuit or opens it again
+	CircuitHalfOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "CLOSED"
+	case CircuitOpen:
+		return "OPEN"
+	case CircuitHalfOpen:
+		return "HALF_OPEN"
+	}
+	return fmt.Sprintf("CircuitBreakerState(%d)", int(s))
+}
+
+// circuitBreaker Counts the consecutive failures of the requests to the server
+type circuitBreaker struct {
+	mutex               sync.Mutex
+	failureThreshold    int
+	probeInterval       time.Duration
+	state               CircuitBreakerState
+	consecutiveFailures int
+	// probing Whether a worker was let through to probe the half open circuit
+	probing bool
+	// stateChannel Closed and replaced on every change of state, waking up the waiting workers
+	stateChannel chan struct{}
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	metrics.RecordCircuitBreakerState(float64(CircuitClosed))
+	return &circuitBreaker{
+		failureThreshold: defaultCircuitBreakerFailureThreshold,
+		probeInterval:    defaultCircuitBreakerProbeInterval,
+		state:            CircuitClosed,
+		stateChannel:     make(chan struct{}),
+	}
+}
+
+// SetCircuitBreaker Suspends polling for every task after failureThreshold consecutive failed requests to the server,
+// probing its health check every probeInterval until it is healthy again.  Failures are transport errors and 5xx responses.
+// Defaults to 5 failures and 1 second, a failureThreshold of 0 disables the circuit breaker
+func (c *TaskRunner) SetCircuitBreaker(failureThreshold int, probeInterval time.Duration) error {
+	if failureThreshold < 0 {
+		return fmt.Errorf("circuit breaker failure threshold can not be negative, got: %d", failureThreshold)
+	}
+	if probeInterval <= 0 {
+		return fmt.Errorf("circuit breaker probe interval must be positive, got: %s", probeInterval)
+	}
+	c.circuitBreaker.mutex.Lock()
+	defer c.circuitBreaker.mutex.Unlock()
+	c.circuitBreaker.failureThreshold = failureThreshold
+	c.circuitBreaker.probeInterval = probeInterval
+	if failureThreshold == 0 && c.circuitBreaker.state != CircuitClosed {
+		c.setCircuitState(CircuitClosed)
+	}
+	return nil
+}
+
+func (c *TaskRunner) GetCircuitBreakerState() CircuitBreakerState {
+	c.circuitBreaker.mutex.Lock()
+	defer c.circuitBreaker.mutex.Unlock()
+	return c.circuitBreaker.state
+}
+
+// waitForCircuit Blocks while the circuit is open, or half open with another worker probing it.
+// The first worker to find the circuit half open is let through to probe it, its poll closes the circuit or opens it again.
+// Returns false if the runner is shutting down
+func (c *TaskRunner) waitForCircuit() bool {
+	for {
+		c.circuitBreaker.mutex.Lock()
+		switch c.circuitBreaker.state {
+		case CircuitClosed:
+			c.circuitBreaker.mutex.Unlock()
+			return true
+		case CircuitHalfOpen:
+			if !c.circuitBreaker.probing {
+				c.circuitBreaker.probing = true
+				c.circuitBreaker.mutex.Unlock()
+				log.Debug("Probing the half open circuit")
+				return true
+			}
+		}
+		stateChannel := c.circuitBreaker.stateChannel
+		c.circuitBreaker.mutex.Unlock()
+		select {
+		case <-stateChannel:
+		case <-c.runnerContext.Done():
+			return false
+		}
+	}
+}
+
+// recordServerResponse Counts the outcome of a request to the server, opening or closing the circuit
+func (c *TaskRunner) recordServerResponse(response *http.Response, err error) {
+	failed := err != nil && (response == nil || response.StatusCode >= http.StatusInternalServerError)
+	c.circuitBreaker.mutex.Lock()
+	defer c.circuitBreaker.mutex.Unlock()
+	if c.circuitBreaker.failureThreshold == 0 {
+		return
+	}
+	if !failed {
+		c.circuitBreaker.consecutiveFailures = 0
+		if c.circuitBreaker.state == CircuitHalfOpen {
+			c.setCircuitState(CircuitClosed)
+		}
+		return
+	}
+	c.circuitBreaker.consecutiveFailures += 1
+	switch c.circuitBreaker.state {
+	case CircuitClosed:
+		if c.circuitBreaker.consecutiveFailures < c.circuitBreaker.failureThreshold {
+			return
+		}
+	case CircuitOpen:
+		return
+	}
+	log.Warning(
+		"Suspending polling after consecutive failed requests to the server",
+		", failures: ", c.circuitBreaker.consecutiveFailures,
+		", reason: ", err.Error(),
+	)
+	c.setCircuitState(CircuitOpen)
+	go c.circuitProbeDaemon(c.circuitBreaker.stateChannel)
+}
+
+// setCircuitState Changes the state of the circuit, the mutex of the circuit breaker must be held
+func (c *TaskRunner) setCircuitState(state CircuitBreakerState) {
+	previous := c.circuitBreaker.state
+	c.circuitBreaker.state = state
+	c.circuitBreaker.probing = false
+	close(c.circuitBreaker.stateChannel)
+	c.circuitBreaker.stateChannel = make(chan struct{})
+	metrics.RecordCircuitBreakerState(float64(state))
+	log.Debug("Changed circuit breaker state from: ", previous, ", to: ", state)
+}
+
+// circuitProbeDaemon Checks the health of the server until it is healthy, moving the circuit to half open.
+// Stops once stateChannel, the channel of the open state it was started for, is closed
+func (c *TaskRunner) circuitProbeDaemon(stateChannel chan struct{}) {
+	defer concurrency.HandlePanicError("circuit_breaker_probe")
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: c.conductorTaskResourceClient.APIClient,
+	}
+	for !c.isShuttingDown() {
+		select {
+		case <-stateChannel:
+			return
+		default:
+		}
+		c.circuitBreaker.mutex.Lock()
+		probeInterval := c.circuitBreaker.probeInterval
+		c.circuitBreaker.mutex.Unlock()
+		c.sleep(probeInterval)
+		status, _, err := healthCheckClient.DoCheck(c.runnerContext)
+		if err != nil || !status.Healthy {
+			log.Debug("Server is still unhealthy, keeping polling suspended")
+			continue
+		}
+		c.circuitBreaker.mutex.Lock()
+		defer c.circuitBreaker.mutex.Unlock()
+		if c.circuitBreaker.state == CircuitOpen && c.circuitBreaker.stateChannel == stateChannel {
+			log.Info("Server is healthy again, resuming polling")
+			c.setCircuitState(CircuitHalfOpen)
+		}
+		return
+	}
+}
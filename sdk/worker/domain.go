@@ -0,0 +1,58 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"strings"
+)
+
+// MultiDomainWorker Worker polling several domains, or isolation groups, with a single batch size.
+// The domains are polled in turn, one per batch, so that a busy domain does not starve the others.
+// When implemented, Domains is used instead of Domain
+type MultiDomainWorker interface {
+	Worker
+	Domains() []string
+}
+
+// WorkerName Name of the worker polling taskName in the given domains, used to address it in the TaskRunner.
+// Follows the naming of the task queues in the server: taskName without domain, domain:taskName otherwise,
+// with the domains separated by commas when the worker polls several of them.
+// The TaskRunner also addresses a worker started with a single domain by its task name, unless other workers poll the task
+func WorkerName(taskName string, domains ...string) string {
+	domain := strings.Join(domains, ",")
+	if domain == "" {
+		return taskName
+	}
+	return domain + ":" + taskName
+}
+
+func getWorkerName(w Worker) string {
+	return WorkerName(w.TaskName(), getDomains(w)...)
+}
+
+func getDomains(w Worker) []string {
+	if multiDomainWorker, ok := w.(MultiDomainWorker); ok {
+		if domains := multiDomainWorker.Domains(); len(domains) > 0 {
+			return domains
+		}
+	}
+	return []string{w.Domain()}
+}
+
+// domainRotation Chooses the domain polled by each batch of a worker, in turn
+type domainRotation struct {
+	next int
+}
+
+func (r *domainRotation) nextDomain(domains []string) string {
+	domain := domains[r.next%len(domains)]
+	r.next = (r.next + 1) % len(domains)
+	return domain
+}
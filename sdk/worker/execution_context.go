@@ -0,0 +1,135 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrExecutionTimeout Matches, with errors.Is, the ExecutionContextError of a task that overran its execution deadline
+var ErrExecutionTimeout = errors.New("task execution timed out")
+
+// ExecutionContextError The execution context was done before the execute function returned,
+// or the execute function returned the error of its done context.
+// The task is reported as failed while the execute function keeps running in the background until it returns,
+// still counted as running by its worker
+type ExecutionContextError struct {
+	Cause   error
+	Timeout time.Duration
+	// returned Closed once the abandoned execute function returns, nil if it had already returned
+	returned <-chan struct{}
+}
+
+func (e *ExecutionContextError) Error() string {
+	if e.Cause == context.DeadlineExceeded {
+		return fmt.Sprintf("%s after %s", ErrExecutionTimeout.Error(), e.Timeout)
+	}
+	return "task execution cancelled, worker is shutting down"
+}
+
+func (e *ExecutionContextError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ExecutionContextError) Is(target error) bool {
+	return target == ErrExecutionTimeout && e.Cause == context.DeadlineExceeded
+}
+
+type executionOutput struct {
+	output interface{}
+	err    error
+}
+
+// newExecutionContext Context for a single task execution, cancelled when the shutdown deadline is reached
+// and, when the task defines a timeout, expiring at the execution deadline counted from the poll of the task
+func (c *TaskRunner) newExecutionContext(polledAt time.Time, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithDeadline(c.executionContext, polledAt.Add(timeout))
+	}
+	return context.WithCancel(c.executionContext)
+}
+
+// getExecutionTimeout The smallest of the task definition timeout and the response timeout.
+// The response timeout is left out when heartbeats are enabled, as they keep extending it
+func (c *TaskRunner) getExecutionTimeout(t *model.Task) time.Duration {
+	var timeoutSeconds int64
+	if t.TaskDefinition != nil && t.TaskDefinition.TimeoutSeconds > 0 {
+		timeoutSeconds = t.TaskDefinition.TimeoutSeconds
+	}
+	responseTimeoutSeconds := getResponseTimeoutSeconds(t)
+	if c.GetHeartbeatFraction() == 0 && responseTimeoutSeconds > 0 {
+		if timeoutSeconds == 0 || responseTimeoutSeconds < timeoutSeconds {
+			timeoutSeconds = responseTimeoutSeconds
+		}
+	}
+	return time.Duration(timeoutSeconds) * time.Second
+}
+
+// executeWithinContext Runs the execute function, returning an ExecutionContextError as soon as ctx is done.
+// An execute function returning the error of its done context gets the same ExecutionContextError,
+// so that the reason for incompletion does not depend on which of them was first
+func executeWithinContext(ctx context.Context, timeout time.Duration, t *model.Task, executeFunction model.ExecuteTaskFunctionWithContext) (interface{}, error) {
+	outputChannel := make(chan executionOutput, 1)
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		output, err := invokeExecuteFunction(ctx, t, executeFunction)
+		outputChannel <- executionOutput{
+			output: output,
+			err:    err,
+		}
+	}()
+	select {
+	case executionOutput := <-outputChannel:
+		if executionOutput.err != nil && ctx.Err() != nil && errors.Is(executionOutput.err, ctx.Err()) {
+			return nil, &ExecutionContextError{
+				Cause:   ctx.Err(),
+				Timeout: timeout,
+			}
+		}
+		return executionOutput.output, executionOutput.err
+	case <-ctx.Done():
+		return nil, &ExecutionContextError{
+			Cause:    ctx.Err(),
+			Timeout:  timeout,
+			returned: returned,
+		}
+	}
+}
+
+// trackAbandonedExecution Keeps an execute function ignoring its context counted as running by the worker until it returns,
+// so that the worker does not exceed its batch size while it keeps running in the background
+func (c *TaskRunner) trackAbandonedExecution(workerName string, t *model.Task, returned <-chan struct{}) {
+	c.increaseRunningWorkers(workerName, 1)
+	metrics.IncrementTaskExecutionAbandoned(t.TaskDefName)
+	log.Warning(
+		"Task execution function is still running after its context is done, it must return when ctx is done",
+		", taskType: ", t.TaskDefName,
+		", taskId: ", t.TaskId,
+		", workflowId: ", t.WorkflowInstanceId,
+	)
+	go func() {
+		<-returned
+		log.Debug(
+			"Abandoned task execution function returned",
+			", taskType: ", t.TaskDefName,
+			", taskId: ", t.TaskId,
+		)
+		c.runningWorkerDone(workerName)
+	}()
+}
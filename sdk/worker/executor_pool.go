@@ -0,0 +1,144 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// executionJob Polled task waiting for an executor of the pool
+type executionJob struct {
+	workerName string
+	worker     Worker
+	task       model.Task
+	polledAt   time.Time
+}
+
+// executorPool Fixed set of executor goroutines shared by all the workers, fed by a bounded queue
+type executorPool struct {
+	queue chan executionJob
+	// pollersDone Closed once the runner is shutting down and no worker is polling anymore
+	pollersDone chan struct{}
+}
+
+// SetExecutorPool Executes the polled tasks of every worker with poolSize long-lived goroutines, instead of one goroutine per task.
+// Polled tasks wait in a queue of up to queueSize tasks, once it is full polling blocks until an executor is free
+// and the task_execution_queue_full metric is incremented.  The execution deadline of the tasks runs from their poll,
+// and tasks that waited longer than their response timeout are skipped, as the server already rescheduled them.
+// The pool can only be set once
+func (c *TaskRunner) SetExecutorPool(poolSize int, queueSize int) error {
+	if poolSize < 1 {
+		return fmt.Errorf("executor pool size must be positive, got: %d", poolSize)
+	}
+	if queueSize < 0 {
+		return fmt.Errorf("executor queue size can not be negative, got: %d", queueSize)
+	}
+	c.executorPoolMutex.Lock()
+	defer c.executorPoolMutex.Unlock()
+	if c.executorPool != nil {
+		return fmt.Errorf("executor pool is already set")
+	}
+	pool := &executorPool{
+		queue:       make(chan executionJob, queueSize),
+		pollersDone: make(chan struct{}),
+	}
+	go func() {
+		<-c.runnerContext.Done()
+		c.workerWaitGroup.Wait()
+		close(pool.pollersDone)
+	}()
+	for i := 0; i < poolSize; i++ {
+		go c.executorDaemon(pool)
+	}
+	c.executorPool = pool
+	log.Debug("Started executor pool of size: ", poolSize, ", with queue size: ", queueSize)
+	return nil
+}
+
+func (c *TaskRunner) getExecutorPool() *executorPool {
+	c.executorPoolMutex.RLock()
+	defer c.executorPoolMutex.RUnlock()
+	return c.executorPool
+}
+
+// submitTask Executes and updates the polled task in the executor pool when set, or in its own goroutine otherwise
+func (c *TaskRunner) submitTask(workerName string, w Worker, task model.Task) {
+	polledAt := time.Now()
+	pool := c.getExecutorPool()
+	if pool == nil {
+		go c.executeAndUpdateTask(workerName, w, task, polledAt)
+		return
+	}
+	job := executionJob{
+		workerName: workerName,
+		worker:     w,
+		task:       task,
+		polledAt:   polledAt,
+	}
+	select {
+	case pool.queue <- job:
+		return
+	default:
+	}
+	metrics.IncrementTaskExecutionQueueFull(w.TaskName())
+	log.Debug("Executor queue is full, waiting to submit task of type: ", w.TaskName(), ", taskId: ", task.TaskId)
+	select {
+	case pool.queue <- job:
+	case <-c.runnerContext.Done():
+		c.abandonTask(workerName, task)
+	}
+}
+
+// executorDaemon Executes the queued tasks, until no worker is polling anymore and the queue is drained
+func (c *TaskRunner) executorDaemon(pool *executorPool) {
+	defer concurrency.HandlePanicError("executor_pool")
+	for {
+		select {
+		case job := <-pool.queue:
+			c.executeJob(job)
+		case <-pool.pollersDone:
+			for {
+				select {
+				case job := <-pool.queue:
+					c.executeJob(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// executeJob Executes a queued task, unless it waited longer than its response timeout and was already rescheduled by the server
+func (c *TaskRunner) executeJob(job executionJob) {
+	responseTimeout := time.Duration(getResponseTimeoutSeconds(&job.task)) * time.Second
+	waited := time.Since(job.polledAt)
+	if responseTimeout > 0 && waited >= responseTimeout {
+		metrics.IncrementTaskExecutionExpired(job.task.TaskDefName)
+		log.Warning(
+			"Skipping task that waited in the execution queue longer than its response timeout",
+			", taskType: ", job.task.TaskDefName,
+			", taskId: ", job.task.TaskId,
+			", workflowId: ", job.task.WorkflowInstanceId,
+			", waited: ", waited,
+		)
+		c.runningWorkerDone(job.workerName)
+		c.inFlightTaskDone(job.task.TaskId)
+		return
+	}
+	c.executeAndUpdateTask(job.workerName, job.worker, job.task, job.polledAt)
+}
@@ -0,0 +1,120 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SetExternalStorageSettings Enables the external payload storage for the task inputs and outputs
+func (c *TaskRunner) SetExternalStorageSettings(externalStorageSettings *settings.ExternalStorageSettings) {
+	c.externalStorageSettingsMutex.Lock()
+	defer c.externalStorageSettingsMutex.Unlock()
+	c.externalStorageSettings = externalStorageSettings
+}
+
+func (c *TaskRunner) getExternalStorageSettings() *settings.ExternalStorageSettings {
+	c.externalStorageSettingsMutex.RLock()
+	defer c.externalStorageSettingsMutex.RUnlock()
+	return c.externalStorageSettings
+}
+
+// downloadExternalInput Replaces the input data of the task with the payload from the external storage, when used
+func (c *TaskRunner) downloadExternalInput(taskName string, t *model.Task) error {
+	if t.ExternalInputPayloadStoragePath == "" {
+		return nil
+	}
+	externalStorageSettings := c.getExternalStorageSettings()
+	if externalStorageSettings == nil || externalStorageSettings.ExternalStorageDownloader == nil {
+		return fmt.Errorf("task input is stored externally at %s, but no external storage downloader is configured", t.ExternalInputPayloadStoragePath)
+	}
+	location, _, err := c.conductorTaskResourceClient.GetExternalStorageLocation1(
+		context.Background(),
+		t.ExternalInputPayloadStoragePath,
+		string(metrics.READ),
+		string(metrics.TASK_INPUT),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get external storage location for path %s, reason: %s", t.ExternalInputPayloadStoragePath, err.Error())
+	}
+	inputData, err := externalStorageSettings.ExternalStorageDownloader(location.Uri)
+	if err != nil {
+		return fmt.Errorf("failed to download task input from %s, reason: %s", location.Uri, err.Error())
+	}
+	metrics.IncrementExternalPayloadUsed(taskName, string(metrics.READ), string(metrics.TASK_INPUT))
+	t.InputData = inputData
+	t.ExternalInputPayloadStoragePath = ""
+	log.Debug(
+		"Downloaded external input for task of type: ", taskName,
+		", taskId: ", t.TaskId,
+		", uri: ", location.Uri,
+	)
+	return nil
+}
+
+// uploadExternalOutput Moves the output data of the task result to the external storage when above the threshold,
+// failing the task when above the max threshold
+func (c *TaskRunner) uploadExternalOutput(taskName string, taskResult *model.TaskResult) {
+	if len(taskResult.OutputData) == 0 {
+		return
+	}
+	payload, err := json.Marshal(taskResult.OutputData)
+	if err != nil {
+		failTaskResult(taskResult, model.FailedWithTerminalErrorTask, fmt.Sprintf("failed to serialize task output, reason: %s", err.Error()))
+		return
+	}
+	payloadSizeKB := int64(len(payload)) / 1024
+	metrics.RecordTaskResultPayloadSize(taskName, float64(len(payload)))
+	externalStorageSettings := c.getExternalStorageSettings()
+	if externalStorageSettings == nil {
+		return
+	}
+	maxThresholdKB := externalStorageSettings.TaskOutputMaxPayloadThresholdKB
+	if maxThresholdKB > 0 && payloadSizeKB > maxThresholdKB {
+		failTaskResult(
+			taskResult,
+			model.FailedWithTerminalErrorTask,
+			fmt.Sprintf("task output payload size %d KB exceeds the max threshold of %d KB", payloadSizeKB, maxThresholdKB),
+		)
+		return
+	}
+	thresholdKB := externalStorageSettings.TaskOutputPayloadThresholdKB
+	if thresholdKB <= 0 || payloadSizeKB <= thresholdKB || externalStorageSettings.ExternalStorageHandler == nil {
+		return
+	}
+	path, err := externalStorageSettings.ExternalStorageHandler(taskResult.OutputData)
+	if err != nil {
+		failTaskResult(taskResult, model.FailedTask, fmt.Sprintf("failed to upload task output to external storage, reason: %s", err.Error()))
+		return
+	}
+	metrics.IncrementExternalPayloadUsed(taskName, string(metrics.WRITE), string(metrics.TASK_OUTPUT))
+	taskResult.ExternalOutputPayloadStoragePath = path
+	taskResult.OutputData = nil
+	log.Debug(
+		"Uploaded external output for task of type: ", taskName,
+		", taskId: ", taskResult.TaskId,
+		", path: ", path,
+		", size: ", payloadSizeKB, " KB",
+	)
+}
+
+func failTaskResult(taskResult *model.TaskResult, status model.TaskResultStatus, reason string) {
+	taskResult.Status = status
+	taskResult.ReasonForIncompletion = reason
+	taskResult.OutputData = nil
+}
@@ -0,0 +1,184 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// taskHeartbeat Keeps a running task alive on the server by sending IN_PROGRESS updates
+type taskHeartbeat struct {
+	taskName string
+	task     *model.Task
+
+	mutex                sync.Mutex
+	outputData           map[string]interface{}
+	callbackAfterSeconds int64
+
+	stopOnce    sync.Once
+	stopChannel chan struct{}
+}
+
+func newTaskHeartbeat(taskName string, task *model.Task) *taskHeartbeat {
+	return &taskHeartbeat{
+		taskName:             taskName,
+		task:                 task,
+		callbackAfterSeconds: getResponseTimeoutSeconds(task),
+		stopChannel:          make(chan struct{}),
+	}
+}
+
+func (h *taskHeartbeat) update(outputData map[string]interface{}, callbackAfterSeconds int64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.outputData = outputData
+	h.callbackAfterSeconds = callbackAfterSeconds
+}
+
+func (h *taskHeartbeat) getTaskResult() *model.TaskResult {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	taskResult := model.NewTaskResultFromTask(h.task)
+	taskResult.Status = model.InProgressTask
+	// Copied as the middlewares may change the output of every heartbeat
+	if h.outputData != nil {
+		taskResult.OutputData = make(map[string]interface{}, len(h.outputData))
+		for key, value := range h.outputData {
+			taskResult.OutputData[key] = value
+		}
+	}
+	taskResult.CallbackAfterSeconds = h.callbackAfterSeconds
+	return taskResult
+}
+
+func (h *taskHeartbeat) stop() {
+	h.stopOnce.Do(func() {
+		close(h.stopChannel)
+	})
+}
+
+// SetHeartbeatFraction Enables automatic heartbeats for the running tasks, sent every time the given
+// fraction of the task response timeout elapses. The fraction must be between 0 and 1, 0 disables automatic heartbeats
+func (c *TaskRunner) SetHeartbeatFraction(fraction float64) error {
+	if fraction < 0 || fraction >= 1 {
+		return fmt.Errorf("heartbeat fraction must be in the range [0, 1), got: %f", fraction)
+	}
+	c.heartbeatFractionMutex.Lock()
+	defer c.heartbeatFractionMutex.Unlock()
+	c.heartbeatFraction = fraction
+	return nil
+}
+
+func (c *TaskRunner) GetHeartbeatFraction() float64 {
+	c.heartbeatFractionMutex.RLock()
+	defer c.heartbeatFractionMutex.RUnlock()
+	return c.heartbeatFraction
+}
+
+// SendHeartbeat Updates a task that is still being executed by this runner as IN_PROGRESS, with partial output.
+// The server postpones the task by callbackAfterSeconds, the response timeout is used when not positive.
+// The partial output and callback are also used by the following automatic heartbeats of the task
+func (c *TaskRunner) SendHeartbeat(t *model.Task, outputData map[string]interface{}, callbackAfterSeconds int64) error {
+	heartbeat, ok := c.getHeartbeat(t.TaskId)
+	if !ok {
+		return fmt.Errorf("task is not running, taskId: %s", t.TaskId)
+	}
+	if callbackAfterSeconds < 1 {
+		callbackAfterSeconds = getResponseTimeoutSeconds(t)
+	}
+	heartbeat.update(outputData, callbackAfterSeconds)
+	return c.sendHeartbeat(heartbeat)
+}
+
+// sendHeartbeat Updates the task as IN_PROGRESS, after the BeforeUpdate hook of the middlewares
+func (c *TaskRunner) sendHeartbeat(heartbeat *taskHeartbeat) error {
+	taskResult := heartbeat.getTaskResult()
+	c.beforeUpdate(heartbeat.task, taskResult)
+	log.Trace(
+		"Sending heartbeat for task of type: ", heartbeat.taskName,
+		", taskId: ", taskResult.TaskId,
+		", workflowId: ", taskResult.WorkflowInstanceId,
+	)
+	_, err := c.updateTask(context.Background(), heartbeat.taskName, taskResult)
+	if err != nil {
+		return fmt.Errorf("failed to send heartbeat for taskId: %s, reason: %s", taskResult.TaskId, err.Error())
+	}
+	return nil
+}
+
+// startHeartbeat Starts the automatic heartbeats of the task, scheduled from its poll so that the
+// time it waited for an executor counts towards the first heartbeat
+func (c *TaskRunner) startHeartbeat(taskName string, task *model.Task, polledAt time.Time) *taskHeartbeat {
+	heartbeat := newTaskHeartbeat(taskName, task)
+	c.heartbeatByTaskIdMutex.Lock()
+	c.heartbeatByTaskId[task.TaskId] = heartbeat
+	c.heartbeatByTaskIdMutex.Unlock()
+	interval := time.Duration(c.GetHeartbeatFraction() * float64(getResponseTimeoutSeconds(task)) * float64(time.Second))
+	if interval > 0 {
+		go c.heartbeatDaemon(heartbeat, interval, interval-time.Since(polledAt))
+	}
+	return heartbeat
+}
+
+func (c *TaskRunner) stopHeartbeat(taskId string) {
+	c.heartbeatByTaskIdMutex.Lock()
+	heartbeat, ok := c.heartbeatByTaskId[taskId]
+	delete(c.heartbeatByTaskId, taskId)
+	c.heartbeatByTaskIdMutex.Unlock()
+	if ok {
+		heartbeat.stop()
+	}
+}
+
+func (c *TaskRunner) getHeartbeat(taskId string) (*taskHeartbeat, bool) {
+	c.heartbeatByTaskIdMutex.RLock()
+	defer c.heartbeatByTaskIdMutex.RUnlock()
+	heartbeat, ok := c.heartbeatByTaskId[taskId]
+	return heartbeat, ok
+}
+
+func (c *TaskRunner) heartbeatDaemon(heartbeat *taskHeartbeat, interval time.Duration, firstDelay time.Duration) {
+	defer concurrency.HandlePanicError("heartbeat")
+	if firstDelay < 0 {
+		firstDelay = 0
+	}
+	timer := time.NewTimer(firstDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-heartbeat.stopChannel:
+			return
+		case <-timer.C:
+			timer.Reset(interval)
+			err := c.sendHeartbeat(heartbeat)
+			if err != nil {
+				log.Warning(err.Error())
+			}
+		}
+	}
+}
+
+func getResponseTimeoutSeconds(t *model.Task) int64 {
+	if t.ResponseTimeoutSeconds > 0 {
+		return t.ResponseTimeoutSeconds
+	}
+	if t.TaskDefinition != nil {
+		return t.TaskDefinition.ResponseTimeoutSeconds
+	}
+	return 0
+}
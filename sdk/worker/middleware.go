@@ -0,0 +1,87 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Middleware Hooks around the polling, execution and update of the tasks of every worker in the TaskRunner.
+// Embed BaseMiddleware to implement only some of the hooks
+type Middleware interface {
+	// BeforePoll Called before polling for tasks, an error skips the poll and backs off as a failed poll
+	BeforePoll(taskName string, domain string) error
+	// AroundExecute Wraps the execute function of the worker, within the execution deadline and panic recovery
+	AroundExecute(taskName string, next model.ExecuteTaskFunctionWithContext) model.ExecuteTaskFunctionWithContext
+	// BeforeUpdate Called with the task result before it is updated on the server, and may modify it
+	BeforeUpdate(t *model.Task, taskResult *model.TaskResult)
+	// AfterUpdate Called once the task result is updated, err is not nil when the update failed
+	AfterUpdate(t *model.Task, taskResult *model.TaskResult, err error)
+}
+
+// BaseMiddleware Middleware without any behaviour
+type BaseMiddleware struct{}
+
+func (m *BaseMiddleware) BeforePoll(taskName string, domain string) error {
+	return nil
+}
+
+func (m *BaseMiddleware) AroundExecute(taskName string, next model.ExecuteTaskFunctionWithContext) model.ExecuteTaskFunctionWithContext {
+	return next
+}
+
+func (m *BaseMiddleware) BeforeUpdate(t *model.Task, taskResult *model.TaskResult) {}
+
+func (m *BaseMiddleware) AfterUpdate(t *model.Task, taskResult *model.TaskResult, err error) {}
+
+// AddMiddleware Appends middlewares to the chain.  Before hooks run in the order they were added,
+// AfterUpdate runs in reverse order, and the first middleware added is the outermost around the execution
+func (c *TaskRunner) AddMiddleware(middlewares ...Middleware) {
+	c.middlewaresMutex.Lock()
+	defer c.middlewaresMutex.Unlock()
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+func (c *TaskRunner) getMiddlewares() []Middleware {
+	c.middlewaresMutex.RLock()
+	defer c.middlewaresMutex.RUnlock()
+	return append([]Middleware(nil), c.middlewares...)
+}
+
+func (c *TaskRunner) beforePoll(taskName string, domain string) error {
+	for _, middleware := range c.getMiddlewares() {
+		err := middleware.BeforePoll(taskName, domain)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *TaskRunner) aroundExecute(taskName string, executeFunction model.ExecuteTaskFunctionWithContext) model.ExecuteTaskFunctionWithContext {
+	middlewares := c.getMiddlewares()
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		executeFunction = middlewares[i].AroundExecute(taskName, executeFunction)
+	}
+	return executeFunction
+}
+
+func (c *TaskRunner) beforeUpdate(t *model.Task, taskResult *model.TaskResult) {
+	for _, middleware := range c.getMiddlewares() {
+		middleware.BeforeUpdate(t, taskResult)
+	}
+}
+
+func (c *TaskRunner) afterUpdate(t *model.Task, taskResult *model.TaskResult, err error) {
+	middlewares := c.getMiddlewares()
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middlewares[i].AfterUpdate(t, taskResult, err)
+	}
+}
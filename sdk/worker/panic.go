@@ -0,0 +1,73 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+//PanicPolicy Defines how a panic within the task execution function is reported to the server
+type PanicPolicy string
+
+const (
+	//ReportPanicAsFailed Reports the task as FAILED, the server retries it according to the task definition
+	ReportPanicAsFailed PanicPolicy = "FAILED"
+	//ReportPanicAsTerminalError Reports the task as FAILED_WITH_TERMINAL_ERROR, the server does not retry it
+	ReportPanicAsTerminalError PanicPolicy = "FAILED_WITH_TERMINAL_ERROR"
+)
+
+//PanicError Error produced when the task execution function panics
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func (p PanicPolicy) taskResultStatus() model.TaskResultStatus {
+	if p == ReportPanicAsTerminalError {
+		return model.FailedWithTerminalErrorTask
+	}
+	return model.FailedTask
+}
+
+func invokeExecuteFunction(ctx context.Context, t *model.Task, executeFunction model.ExecuteTaskFunctionWithContext) (output interface{}, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{
+				Value: value,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+	return executeFunction(ctx, t)
+}
+
+func newTaskResultFromPanic(t *model.Task, panicError *PanicError, policy PanicPolicy) *model.TaskResult {
+	taskResult := model.NewTaskResultFromTask(t)
+	taskResult.Status = policy.taskResultStatus()
+	taskResult.ReasonForIncompletion = fmt.Sprintf("%s\n%s", panicError.Error(), panicError.Stack)
+	taskResult.Logs = append(
+		taskResult.Logs,
+		model.TaskExecLog{
+			Log:         taskResult.ReasonForIncompletion,
+			TaskId:      t.TaskId,
+			CreatedTime: time.Now().UnixMilli(),
+		},
+	)
+	return taskResult
+}
@@ -0,0 +1,81 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PauseAllWorkersEnv Environment variable to start every worker paused, when set to true
+const PauseAllWorkersEnv = "CONDUCTOR_WORKER_ALL_PAUSED"
+
+// PauseWorker Stops polling for the task while keeping the worker registered with its configuration.
+// Tasks already polled are still executed and updated.  Workers polling several domains are paused by their WorkerName
+func (c *TaskRunner) PauseWorker(taskName string) error {
+	taskName = c.workerRegistry.resolve(taskName)
+	if _, ok := c.workerRegistry.get(taskName); !ok {
+		return fmt.Errorf("no worker registered for taskName: %s", taskName)
+	}
+	c.setPaused(taskName, true)
+	log.Info("Paused worker for task: ", taskName)
+	return nil
+}
+
+// ResumeWorker Resumes polling for a paused task
+func (c *TaskRunner) ResumeWorker(taskName string) error {
+	taskName = c.workerRegistry.resolve(taskName)
+	if _, ok := c.workerRegistry.get(taskName); !ok {
+		return fmt.Errorf("no worker registered for taskName: %s", taskName)
+	}
+	c.setPaused(taskName, false)
+	c.notifyCapacityChanged(taskName)
+	log.Info("Resumed worker for task: ", taskName)
+	return nil
+}
+
+func (c *TaskRunner) IsWorkerPaused(taskName string) bool {
+	taskName = c.workerRegistry.resolve(taskName)
+	c.pausedByTaskNameMutex.RLock()
+	defer c.pausedByTaskNameMutex.RUnlock()
+	return c.pausedByTaskName[taskName]
+}
+
+func (c *TaskRunner) setPaused(taskName string, paused bool) {
+	c.pausedByTaskNameMutex.Lock()
+	defer c.pausedByTaskNameMutex.Unlock()
+	c.pausedByTaskName[taskName] = paused
+}
+
+// isPausedByEnv Whether the worker must start paused, according to CONDUCTOR_WORKER_ALL_PAUSED
+// or CONDUCTOR_WORKER_<TASK_NAME>_PAUSED, with the task name in upper case and other characters than letters and digits as underscores
+func isPausedByEnv(taskName string) bool {
+	for _, name := range []string{PauseAllWorkersEnv, pauseWorkerEnv(taskName)} {
+		paused, err := strconv.ParseBool(os.Getenv(name))
+		if err == nil && paused {
+			return true
+		}
+	}
+	return false
+}
+
+func pauseWorkerEnv(taskName string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, taskName)
+	return "CONDUCTOR_WORKER_" + strings.ToUpper(name) + "_PAUSED"
+}
@@ -0,0 +1,146 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tokenBucket Allows up to burst executions at once, refilled at rate executions per second
+type tokenBucket struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// take Removes up to max whole tokens, returning how many were taken, and how long until the next token when none
+func (b *tokenBucket) take(max int) (int, time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	taken := int(math.Min(math.Floor(b.tokens), float64(max)))
+	if taken < 1 {
+		return 0, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	b.tokens -= float64(taken)
+	return taken, 0
+}
+
+// refund Gives back tokens taken but not used
+func (b *tokenBucket) refund(amount int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+float64(amount))
+}
+
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.lastRefill).Seconds()*b.rate)
+	b.lastRefill = now
+}
+
+// SetRateLimit Caps the executions of the task by this runner to executionsPerSecond, across all its goroutines,
+// allowing bursts of up to burst executions.  Tasks are only polled when there is capacity to execute them.
+// A rate of 0 removes the limit
+func (c *TaskRunner) SetRateLimit(taskName string, executionsPerSecond float64, burst int) error {
+	if executionsPerSecond < 0 {
+		return fmt.Errorf("rate limit can not be negative, got: %f", executionsPerSecond)
+	}
+	c.rateLimiterByTaskNameMutex.Lock()
+	defer c.rateLimiterByTaskNameMutex.Unlock()
+	if executionsPerSecond == 0 {
+		delete(c.rateLimiterByTaskName, taskName)
+		log.Debug("Removed rate limit for task: ", taskName)
+		return nil
+	}
+	if burst < 1 {
+		return fmt.Errorf("rate limit burst must be positive, got: %d", burst)
+	}
+	c.rateLimiterByTaskName[taskName] = newTokenBucket(executionsPerSecond, burst)
+	log.Debug(
+		"Set rate limit for task: ", taskName,
+		", executionsPerSecond: ", executionsPerSecond,
+		", burst: ", burst,
+	)
+	return nil
+}
+
+// SetRateLimitFromTaskDef Applies the rate limit of the task definition registered on the server,
+// RateLimitPerFrequency executions every RateLimitFrequencyInSeconds, to this runner
+func (c *TaskRunner) SetRateLimitFromTaskDef(taskName string) error {
+	metadataClient := &client.MetadataResourceApiService{
+		APIClient: c.conductorTaskResourceClient.APIClient,
+	}
+	taskDef, _, err := metadataClient.GetTaskDef(context.Background(), taskName)
+	if err != nil {
+		return fmt.Errorf("failed to get task definition for taskName: %s, reason: %s", taskName, err.Error())
+	}
+	if taskDef.RateLimitPerFrequency < 1 || taskDef.RateLimitFrequencyInSeconds < 1 {
+		return c.SetRateLimit(taskName, 0, 0)
+	}
+	return c.SetRateLimit(
+		taskName,
+		float64(taskDef.RateLimitPerFrequency)/float64(taskDef.RateLimitFrequencyInSeconds),
+		int(taskDef.RateLimitPerFrequency),
+	)
+}
+
+func (c *TaskRunner) getRateLimiter(taskName string) *tokenBucket {
+	c.rateLimiterByTaskNameMutex.RLock()
+	defer c.rateLimiterByTaskNameMutex.RUnlock()
+	return c.rateLimiterByTaskName[taskName]
+}
+
+// acquireRateLimit Blocks until at least one execution is allowed, returning how many of the batch are allowed.
+// Returns 0 when the runner is shutting down
+func (c *TaskRunner) acquireRateLimit(taskName string, batchSize int) int {
+	for !c.isShuttingDown() {
+		rateLimiter := c.getRateLimiter(taskName)
+		if rateLimiter == nil {
+			return batchSize
+		}
+		allowed, wait := rateLimiter.take(batchSize)
+		if allowed > 0 {
+			return allowed
+		}
+		c.sleep(wait)
+	}
+	return 0
+}
+
+// releaseRateLimit Gives back the executions acquired but not used by the poll
+func (c *TaskRunner) releaseRateLimit(taskName string, unused int) {
+	if unused < 1 {
+		return
+	}
+	rateLimiter := c.getRateLimiter(taskName)
+	if rateLimiter != nil {
+		rateLimiter.refund(unused)
+	}
+}
@@ -0,0 +1,174 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type taskLoggerContextKey struct{}
+
+// TaskLogger Collects the log lines of a single task execution, shown by the Conductor UI along with the task.
+// Lines are attached to the task result, or sent earlier when log flushing is enabled on the TaskRunner
+type TaskLogger struct {
+	taskId             string
+	taskType           string
+	workflowInstanceId string
+	workerId           string
+
+	mutex sync.Mutex
+	logs  []model.TaskExecLog
+}
+
+func newTaskLogger(t *model.Task, workerId string) *TaskLogger {
+	return &TaskLogger{
+		taskId:             t.TaskId,
+		taskType:           t.TaskDefName,
+		workflowInstanceId: t.WorkflowInstanceId,
+		workerId:           workerId,
+	}
+}
+
+// GetTaskLogger Returns the logger of the task being executed with ctx.
+// Outside of a task execution, a logger that only writes to the local log is returned
+func GetTaskLogger(ctx context.Context) *TaskLogger {
+	if ctx != nil {
+		if taskLogger, ok := ctx.Value(taskLoggerContextKey{}).(*TaskLogger); ok {
+			return taskLogger
+		}
+	}
+	return &TaskLogger{}
+}
+
+func withTaskLogger(ctx context.Context, taskLogger *TaskLogger) context.Context {
+	return context.WithValue(ctx, taskLoggerContextKey{}, taskLogger)
+}
+
+// Log Adds a log line, with its operands formatted as in fmt.Sprint
+func (l *TaskLogger) Log(args ...interface{}) {
+	l.add(fmt.Sprint(args...))
+}
+
+// Logf Adds a log line, formatted as in fmt.Sprintf
+func (l *TaskLogger) Logf(format string, args ...interface{}) {
+	l.add(fmt.Sprintf(format, args...))
+}
+
+func (l *TaskLogger) add(line string) {
+	log.Debug(
+		line,
+		", taskType: ", l.taskType,
+		", taskId: ", l.taskId,
+		", workflowId: ", l.workflowInstanceId,
+		", workerId: ", l.workerId,
+	)
+	if l.taskId == "" {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.logs = append(l.logs, model.TaskExecLog{
+		Log:         line,
+		TaskId:      l.taskId,
+		CreatedTime: time.Now().UnixMilli(),
+	})
+}
+
+// drain Takes the log lines that were not sent yet
+func (l *TaskLogger) drain() []model.TaskExecLog {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	logs := l.logs
+	l.logs = nil
+	return logs
+}
+
+// restore Puts back log lines that failed to be sent, ahead of the newer ones
+func (l *TaskLogger) restore(logs []model.TaskExecLog) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.logs = append(logs, l.logs...)
+}
+
+// SetTaskLogFlushInterval Sends the log lines of the running tasks to the server in the given interval,
+// instead of only along with the task result. Zero disables incremental flushing
+func (c *TaskRunner) SetTaskLogFlushInterval(interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("task log flush interval can not be negative")
+	}
+	c.taskLogFlushIntervalMutex.Lock()
+	defer c.taskLogFlushIntervalMutex.Unlock()
+	c.taskLogFlushInterval = interval
+	return nil
+}
+
+func (c *TaskRunner) GetTaskLogFlushInterval() time.Duration {
+	c.taskLogFlushIntervalMutex.RLock()
+	defer c.taskLogFlushIntervalMutex.RUnlock()
+	return c.taskLogFlushInterval
+}
+
+// startTaskLogFlush Starts flushing the task logs when enabled, returns the function that stops it
+func (c *TaskRunner) startTaskLogFlush(taskName string, taskLogger *TaskLogger) func() {
+	interval := c.GetTaskLogFlushInterval()
+	if interval <= 0 {
+		return func() {}
+	}
+	stopChannel := make(chan struct{})
+	doneChannel := make(chan struct{})
+	go c.taskLogFlushDaemon(taskName, taskLogger, interval, stopChannel, doneChannel)
+	return func() {
+		close(stopChannel)
+		<-doneChannel
+	}
+}
+
+func (c *TaskRunner) taskLogFlushDaemon(taskName string, taskLogger *TaskLogger, interval time.Duration, stopChannel chan struct{}, doneChannel chan struct{}) {
+	defer close(doneChannel)
+	defer concurrency.HandlePanicError("task_log_flush")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopChannel:
+			return
+		case <-ticker.C:
+			err := c.flushTaskLogs(taskLogger)
+			if err != nil {
+				log.Warning(
+					"Failed to flush task logs",
+					", reason: ", err.Error(),
+					", taskType: ", taskName,
+					", taskId: ", taskLogger.taskId,
+				)
+			}
+		}
+	}
+}
+
+func (c *TaskRunner) flushTaskLogs(taskLogger *TaskLogger) error {
+	logs := taskLogger.drain()
+	for i, taskExecLog := range logs {
+		_, err := c.conductorTaskResourceClient.Log(context.Background(), taskExecLog.Log, taskExecLog.TaskId)
+		if err != nil {
+			taskLogger.restore(logs[i:])
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const taskResultOutboxFileName = "task_results.jsonl"
+const taskResultOutboxReplayInterval = 1 * time.Second
+const taskResultOutboxMaxReplayInterval = 1 * time.Minute
+
+// taskResultOutboxEntry Single line of the outbox file
+type taskResultOutboxEntry struct {
+	TaskName   string           `json:"taskName"`
+	TaskResult model.TaskResult `json:"taskResult"`
+}
+
+// TaskResultOutbox Keeps the task results that could not be updated on disk, as JSON lines,
+// until they are delivered to the server.  Results persisted by a previous process are loaded when opened
+type TaskResultOutbox struct {
+	filePath string
+
+	mutex          sync.Mutex
+	entries        []taskResultOutboxEntry
+	sizeByTaskName map[string]int
+}
+
+// NewTaskResultOutbox Opens the outbox stored within the directory, creating the directory if needed
+func NewTaskResultOutbox(directory string) (*TaskResultOutbox, error) {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+	outbox := &TaskResultOutbox{
+		filePath:       filepath.Join(directory, taskResultOutboxFileName),
+		sizeByTaskName: make(map[string]int),
+	}
+	entries, err := outbox.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task result outbox from %s, reason: %s", outbox.filePath, err.Error())
+	}
+	outbox.entries = entries
+	outbox.recordSize()
+	return outbox, nil
+}
+
+// Add Persists a task result to be delivered later
+func (o *TaskResultOutbox) Add(taskName string, taskResult *model.TaskResult) error {
+	entry := taskResultOutboxEntry{
+		TaskName:   taskName,
+		TaskResult: *taskResult,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	file, err := os.OpenFile(o.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return err
+	}
+	err = file.Sync()
+	if err != nil {
+		return err
+	}
+	o.entries = append(o.entries, entry)
+	o.recordSize()
+	return nil
+}
+
+// Size Amount of task results waiting to be delivered
+func (o *TaskResultOutbox) Size() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return len(o.entries)
+}
+
+func (o *TaskResultOutbox) load() ([]taskResultOutboxEntry, error) {
+	file, err := os.Open(o.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	entries := make([]taskResultOutboxEntry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry taskResultOutboxEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			// A line may be truncated when the process stopped while writing it
+			log.Warning("Skipping malformed task result outbox entry, reason: ", err.Error())
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// snapshot Entries currently waiting, new entries are only appended after them
+func (o *TaskResultOutbox) snapshot() []taskResultOutboxEntry {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]taskResultOutboxEntry(nil), o.entries...)
+}
+
+// remove Drops the first entries that were handled by a replay, rewriting the outbox file
+func (o *TaskResultOutbox) remove(amount int) error {
+	if amount < 1 {
+		return nil
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	remaining := o.entries[amount:]
+	temporaryFilePath := o.filePath + ".tmp"
+	file, err := os.Create(temporaryFilePath)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, entry := range remaining {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(append(line, '\n'))
+	}
+	err = writer.Flush()
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(temporaryFilePath, o.filePath)
+	if err != nil {
+		return err
+	}
+	o.entries = append([]taskResultOutboxEntry(nil), remaining...)
+	o.recordSize()
+	return nil
+}
+
+func (o *TaskResultOutbox) recordSize() {
+	for taskName := range o.sizeByTaskName {
+		o.sizeByTaskName[taskName] = 0
+	}
+	for _, entry := range o.entries {
+		o.sizeByTaskName[entry.TaskName] += 1
+	}
+	for taskName, size := range o.sizeByTaskName {
+		metrics.RecordTaskResultOutboxSize(taskName, float64(size))
+	}
+}
+
+// SetTaskResultOutbox Persists the task results that fail to be updated after all the retries into the outbox,
+// and replays them in the background until delivered.  Results already in the outbox are replayed right away
+func (c *TaskRunner) SetTaskResultOutbox(outbox *TaskResultOutbox) {
+	c.taskResultOutboxMutex.Lock()
+	defer c.taskResultOutboxMutex.Unlock()
+	c.taskResultOutbox = outbox
+	if outbox != nil {
+		go c.taskResultOutboxDaemon(outbox)
+	}
+}
+
+func (c *TaskRunner) getTaskResultOutbox() *TaskResultOutbox {
+	c.taskResultOutboxMutex.RLock()
+	defer c.taskResultOutboxMutex.RUnlock()
+	return c.taskResultOutbox
+}
+
+func (c *TaskRunner) taskResultOutboxDaemon(outbox *TaskResultOutbox) {
+	defer concurrency.HandlePanicError("task_result_outbox")
+	backoff := NewExponentialBackoff(taskResultOutboxMaxReplayInterval)
+	failedReplays := 0
+	for !c.isShuttingDown() && c.getTaskResultOutbox() == outbox {
+		err := c.replayTaskResultOutbox(outbox)
+		if err == nil {
+			failedReplays = 0
+			c.sleep(taskResultOutboxReplayInterval)
+			continue
+		}
+		log.Debug("Failed to replay task results from outbox, reason: ", err.Error())
+		failedReplays += 1
+		c.sleep(backoff.NextDelay(taskResultOutboxReplayInterval, failedReplays))
+	}
+}
+
+// replayTaskResultOutbox Delivers the task results in the outbox in order, stopping on the first failure.
+// Results rejected by the server are dropped, as they would never be accepted
+func (c *TaskRunner) replayTaskResultOutbox(outbox *TaskResultOutbox) error {
+	entries := outbox.snapshot()
+	handled := 0
+	var replayError error
+	for _, entry := range entries {
+		if c.isShuttingDown() {
+			break
+		}
+		taskResult := entry.TaskResult
+		response, err := c.updateTask(context.Background(), entry.TaskName, &taskResult)
+		if err != nil && !isRejectedByServer(response) {
+			replayError = err
+			break
+		}
+		if err != nil {
+			log.Warning(
+				"Dropping task result rejected by the server",
+				", reason: ", err.Error(),
+				", task type: ", entry.TaskName,
+				", taskId: ", taskResult.TaskId,
+				", workflowId: ", taskResult.WorkflowInstanceId,
+			)
+		} else {
+			log.Debug(
+				"Replayed task result from outbox",
+				", task type: ", entry.TaskName,
+				", taskId: ", taskResult.TaskId,
+			)
+		}
+		handled += 1
+	}
+	err := outbox.remove(handled)
+	if err != nil {
+		log.Error("Failed to remove replayed task results from outbox, reason: ", err.Error())
+	}
+	return replayError
+}
+
+// isRejectedByServer Whether the update failed due to the task result itself, rather than authorization or availability
+func isRejectedByServer(response *http.Response) bool {
+	if response == nil || response.StatusCode < 400 || response.StatusCode >= 500 {
+		return false
+	}
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
@@ -11,9 +11,10 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/metrics"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
 
 	"github.com/antihax/optional"
 	log "github.com/sirupsen/logrus"
@@ -29,15 +31,18 @@ import (
 
 const taskUpdateRetryAttemptsLimit = 3
 const batchPollErrorRetryInterval = 100 * time.Millisecond
-const batchPollNoAvailableWorkerRetryInterval = 1 * time.Millisecond
+const batchPollErrorMaxRetryInterval = 10 * time.Second
+const executeTimeSmoothingFactor = 0.2
 
-var hostname, _ = os.Hostname()
+// pollRequestMargin Time on top of the poll timeout given to a poll request to get its response
+const pollRequestMargin = 5 * time.Second
 
 //TaskRunner Runner for the Task Workers.  Task Runners implements the polling and execution logic for the workers
 type TaskRunner struct {
 	conductorTaskResourceClient *client.TaskResourceApiService
 
 	workerWaitGroup sync.WaitGroup
+	workerRegistry  *workerRegistry
 
 	batchSizeByTaskNameMutex sync.RWMutex
 	batchSizeByTaskName      map[string]int
@@ -47,6 +52,69 @@ type TaskRunner struct {
 
 	pollIntervalByTaskNameMutex sync.RWMutex
 	pollIntervalByTaskName      map[string]time.Duration
+
+	runnerContext       context.Context
+	cancelRunnerContext context.CancelFunc
+
+	executionContext       context.Context
+	cancelExecutionContext context.CancelFunc
+
+	inFlightTasksWaitGroup sync.WaitGroup
+	inFlightTasksMutex     sync.RWMutex
+	inFlightTasks          map[string]model.Task
+	abandonedTasks         []model.Task
+
+	panicPolicyMutex sync.RWMutex
+	panicPolicy      PanicPolicy
+
+	heartbeatFractionMutex sync.RWMutex
+	heartbeatFraction      float64
+
+	heartbeatByTaskIdMutex sync.RWMutex
+	heartbeatByTaskId      map[string]*taskHeartbeat
+
+	taskLogFlushIntervalMutex sync.RWMutex
+	taskLogFlushInterval      time.Duration
+
+	externalStorageSettingsMutex sync.RWMutex
+	externalStorageSettings      *settings.ExternalStorageSettings
+
+	backoffStrategyMutex     sync.RWMutex
+	errorBackoffStrategy     BackoffStrategy
+	emptyPollBackoffStrategy BackoffStrategy
+
+	pollTimeoutMutex sync.RWMutex
+	pollTimeout      time.Duration
+
+	capacityChannelByTaskNameMutex sync.Mutex
+	capacityChannelByTaskName      map[string]chan struct{}
+
+	taskResultOutboxMutex sync.RWMutex
+	taskResultOutbox      *TaskResultOutbox
+
+	middlewaresMutex sync.RWMutex
+	middlewares      []Middleware
+
+	pausedByTaskNameMutex sync.RWMutex
+	pausedByTaskName      map[string]bool
+
+	workerErrorsMutex         sync.RWMutex
+	lastPollErrorByTaskName   map[string]*WorkerError
+	lastUpdateErrorByTaskName map[string]*WorkerError
+
+	averageExecuteTimeByTaskNameMutex sync.RWMutex
+	averageExecuteTimeByTaskName      map[string]time.Duration
+
+	rateLimiterByTaskNameMutex sync.RWMutex
+	rateLimiterByTaskName      map[string]*tokenBucket
+
+	workerIdStrategyMutex sync.RWMutex
+	workerIdStrategy      WorkerIdStrategy
+
+	circuitBreaker *circuitBreaker
+
+	executorPoolMutex sync.RWMutex
+	executorPool      *executorPool
 }
 
 func NewTaskRunner(authenticationSettings *settings.AuthenticationSettings, httpSettings *settings.HttpSettings) *TaskRunner {
@@ -60,13 +128,34 @@ func NewTaskRunner(authenticationSettings *settings.AuthenticationSettings, http
 func NewTaskRunnerWithApiClient(
 	apiClient *client.APIClient,
 ) *TaskRunner {
+	runnerContext, cancelRunnerContext := context.WithCancel(context.Background())
+	executionContext, cancelExecutionContext := context.WithCancel(context.Background())
 	return &TaskRunner{
 		conductorTaskResourceClient: &client.TaskResourceApiService{
 			APIClient: apiClient,
 		},
-		batchSizeByTaskName:      make(map[string]int),
-		runningWorkersByTaskName: make(map[string]int),
-		pollIntervalByTaskName:   make(map[string]time.Duration),
+		workerRegistry:               newWorkerRegistry(),
+		batchSizeByTaskName:          make(map[string]int),
+		runningWorkersByTaskName:     make(map[string]int),
+		pollIntervalByTaskName:       make(map[string]time.Duration),
+		runnerContext:                runnerContext,
+		cancelRunnerContext:          cancelRunnerContext,
+		executionContext:             executionContext,
+		cancelExecutionContext:       cancelExecutionContext,
+		inFlightTasks:                make(map[string]model.Task),
+		panicPolicy:                  ReportPanicAsFailed,
+		heartbeatByTaskId:            make(map[string]*taskHeartbeat),
+		errorBackoffStrategy:         NewExponentialBackoff(batchPollErrorMaxRetryInterval),
+		emptyPollBackoffStrategy:     NewExponentialBackoff(defaultEmptyPollMaxDelay),
+		pollTimeout:                  defaultPollTimeout,
+		capacityChannelByTaskName:    make(map[string]chan struct{}),
+		pausedByTaskName:             make(map[string]bool),
+		lastPollErrorByTaskName:      make(map[string]*WorkerError),
+		lastUpdateErrorByTaskName:    make(map[string]*WorkerError),
+		averageExecuteTimeByTaskName: make(map[string]time.Duration),
+		rateLimiterByTaskName:        make(map[string]*tokenBucket),
+		workerIdStrategy:             HostnameWorkerId(),
+		circuitBreaker:               newCircuitBreaker(),
 	}
 }
 
@@ -77,7 +166,25 @@ func NewTaskRunnerWithApiClient(
 //  - pollInterval Time to wait for between polls if there are no tasks available. Reduces excessive polling on the server when there is no work
 //  - domain Task domain. Optional for polling
 func (c *TaskRunner) StartWorkerWithDomain(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration, domain string) error {
-	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, domain)
+	return c.startWorker(newFunctionWorker(taskName, model.NewExecuteTaskFunctionWithContext(executeFunction), batchSize, pollInterval, domain))
+}
+
+// StartWorkerWithDomains Same as StartWorkerWithDomain, polling each of the domains in turn and sharing the batch size among them.
+// The worker is named after its task name and domains, see WorkerName
+func (c *TaskRunner) StartWorkerWithDomains(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration, domains []string) error {
+	w := newFunctionWorker(taskName, model.NewExecuteTaskFunctionWithContext(executeFunction), batchSize, pollInterval, domains...)
+	err := validateWorker(w)
+	if err != nil {
+		return err
+	}
+	return c.startWorker(w)
+}
+
+// StartContextWorker Same as StartWorkerWithDomain, for an execute function that receives the execution context.
+// The context is done when the execution deadline derived from the task timeouts is reached,
+// or when the runner gives up on the task at the shutdown deadline
+func (c *TaskRunner) StartContextWorker(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration, domain string) error {
+	return c.startWorker(newFunctionWorker(taskName, executeFunction, batchSize, pollInterval, domain))
 }
 
 // StartWorker
@@ -86,14 +193,102 @@ func (c *TaskRunner) StartWorkerWithDomain(taskName string, executeFunction mode
 //  - batchSize Amount of tasks to be polled. Each polled task will be executed and updated within its own unique goroutine.
 //  - pollInterval Time to wait for between polls if there are no tasks available. Reduces excessive polling on the server when there is no work
 func (c *TaskRunner) StartWorker(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration) error {
-	return c.startWorker(taskName, executeFunction, batchSize, pollInterval, "")
+	return c.startWorker(newFunctionWorker(taskName, model.NewExecuteTaskFunctionWithContext(executeFunction), batchSize, pollInterval, ""))
+}
+
+// StartWorkers Starts polling and executing the work for each of the given workers.
+// All the workers are validated before any of them is started
+func (c *TaskRunner) StartWorkers(workers ...Worker) error {
+	for _, w := range workers {
+		err := validateWorker(w)
+		if err != nil {
+			return err
+		}
+	}
+	for _, w := range workers {
+		err := c.startWorker(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconfigureWorker Replaces the definition of an already registered worker without stopping it.
+// The worker is matched by its task name and domains, see WorkerName.  The new execute function and identity
+// are used from the next poll on, while batch size and poll interval are applied right away
+func (c *TaskRunner) ReconfigureWorker(w Worker) error {
+	err := validateWorker(w)
+	if err != nil {
+		return err
+	}
+	workerName := getWorkerName(w)
+	if _, ok := c.workerRegistry.get(workerName); !ok {
+		return fmt.Errorf("no worker registered for taskName: %s", workerName)
+	}
+	err = c.SetBatchSize(workerName, w.BatchSize())
+	if err != nil {
+		return err
+	}
+	c.setPollInterval(workerName, w.PollInterval())
+	c.workerRegistry.register(workerName, w)
+	log.Info("Reconfigured worker for task: ", workerName)
+	return nil
+}
+
+// DescribeWorker Returns the current configuration and state of the worker registered as workerName,
+// which is the task name for the workers polling without domain or in a single one, see WorkerName
+func (c *TaskRunner) DescribeWorker(workerName string) (*WorkerDescription, error) {
+	workerName = c.workerRegistry.resolve(workerName)
+	w, ok := c.workerRegistry.get(workerName)
+	if !ok {
+		return nil, fmt.Errorf("no worker registered for taskName: %s", workerName)
+	}
+	pollInterval, err := c.GetPollIntervalForTask(workerName)
+	if err != nil {
+		return nil, err
+	}
+	runningTasks, err := c.getRunningWorkers(workerName)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkerDescription{
+		Name:            workerName,
+		TaskName:        w.TaskName(),
+		Domain:          w.Domain(),
+		Domains:         getDomains(w),
+		Identity:        c.getWorkerId(w),
+		BatchSize:       c.GetBatchSizeForTask(workerName),
+		PollInterval:    pollInterval,
+		RunningTasks:    runningTasks,
+		Paused:          c.IsWorkerPaused(workerName),
+		LastPollError:   c.getLastPollError(workerName),
+		LastUpdateError: c.getLastUpdateError(workerName),
+	}, nil
+}
+
+// ListWorkers Returns the description of every registered worker, sorted by name
+func (c *TaskRunner) ListWorkers() []WorkerDescription {
+	workerNames := c.workerRegistry.names()
+	workers := make([]WorkerDescription, 0, len(workerNames))
+	for _, workerName := range workerNames {
+		description, err := c.DescribeWorker(workerName)
+		if err != nil {
+			continue
+		}
+		workers = append(workers, *description)
+	}
+	return workers
 }
 
+// SetBatchSize Sets the number of tasks the worker polls and executes concurrently.
+// With a batch size of 0 the worker stops polling, until its batch size is increased again
 func (c *TaskRunner) SetBatchSize(taskName string, batchSize int) error {
+	taskName = c.workerRegistry.resolve(taskName)
 	if batchSize < 0 {
 		return fmt.Errorf("batchSize can not be negative")
 	}
-	if !c.isWorkerAlive(taskName) {
+	if !c.isWorkerRegistered(taskName) {
 		return fmt.Errorf("no worker registered for taskName: %s", taskName)
 	}
 	c.batchSizeByTaskNameMutex.Lock()
@@ -105,19 +300,16 @@ func (c *TaskRunner) SetBatchSize(taskName string, batchSize int) error {
 		", from: ", previous,
 		", to: ", c.batchSizeByTaskName[taskName],
 	)
-	if batchSize == 0 {
-		log.Info("Stopped worker for task: ", taskName)
-	} else if previous == 0 && c.batchSizeByTaskName[taskName] > 0 {
-		log.Info("Started worker for task: ", taskName)
-	}
+	c.notifyCapacityChanged(taskName)
 	return nil
 }
 
 func (c *TaskRunner) IncreaseBatchSize(taskName string, batchSize int) error {
+	taskName = c.workerRegistry.resolve(taskName)
 	if batchSize < 1 {
 		return fmt.Errorf("batchSize value must be positive")
 	}
-	if !c.isWorkerAlive(taskName) {
+	if !c.isWorkerRegistered(taskName) {
 		return fmt.Errorf("no worker registered for taskName: %s", taskName)
 	}
 	c.batchSizeByTaskNameMutex.Lock()
@@ -129,17 +321,16 @@ func (c *TaskRunner) IncreaseBatchSize(taskName string, batchSize int) error {
 		", from: ", previous,
 		", to: ", c.batchSizeByTaskName[taskName],
 	)
-	if previous == 0 {
-		log.Info("Started worker for task: ", taskName)
-	}
+	c.notifyCapacityChanged(taskName)
 	return nil
 }
 
 func (c *TaskRunner) DecreaseBatchSize(taskName string, batchSize int) error {
+	taskName = c.workerRegistry.resolve(taskName)
 	if batchSize < 1 {
 		return fmt.Errorf("batchSize value must be positive")
 	}
-	if !c.isWorkerAlive(taskName) {
+	if !c.isWorkerRegistered(taskName) {
 		return fmt.Errorf("no worker registered for taskName: %s", taskName)
 	}
 	c.batchSizeByTaskNameMutex.Lock()
@@ -153,8 +344,9 @@ func (c *TaskRunner) DecreaseBatchSize(taskName string, batchSize int) error {
 	)
 	if previous-batchSize <= 0 {
 		c.batchSizeByTaskName[taskName] = 0
-		log.Info("Stopped worker for task: ", taskName)
+		log.Info("Stopped polling for task: ", taskName, ", until its batch size is increased")
 	}
+	c.notifyCapacityChanged(taskName)
 	return nil
 }
 
@@ -162,96 +354,256 @@ func (c *TaskRunner) WaitWorkers() {
 	c.workerWaitGroup.Wait()
 }
 
-func (c *TaskRunner) startWorker(taskName string, executeFunction model.ExecuteTaskFunction, batchSize int, pollInterval time.Duration, taskDomain string) error {
-	c.SetPollIntervalForTask(taskName, pollInterval)
-	previousMaxAllowedWorkers, err := c.getMaxAllowedWorkers(taskName)
-	if err != nil {
-		return err
+// StartWorkersWithContext Binds the lifecycle of the runner to ctx.
+// Once ctx is done the runner is shut down, giving the in-flight tasks up to shutdownTimeout to be executed and updated.
+// The returned channel receives the shutdown report and is closed afterwards.
+func (c *TaskRunner) StartWorkersWithContext(ctx context.Context, shutdownTimeout time.Duration) <-chan *ShutdownReport {
+	reportChannel := make(chan *ShutdownReport, 1)
+	go func() {
+		defer close(reportChannel)
+		defer concurrency.HandlePanicError("start_workers_with_context")
+		select {
+		case <-ctx.Done():
+		case <-c.runnerContext.Done():
+		}
+		shutdownContext, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		report, err := c.Shutdown(shutdownContext)
+		if err != nil {
+			log.Warning(
+				"Shutdown did not complete gracefully",
+				", reason: ", err.Error(),
+				", abandoned tasks: ", len(report.AbandonedTasks),
+			)
+		}
+		reportChannel <- report
+	}()
+	return reportChannel
+}
+
+// Shutdown Stops polling for all the workers and waits for the in-flight tasks to be executed and updated.
+// Polls already sent to the server are completed, and the tasks they return are executed as well,
+// unless the executor pool is full: these tasks are abandoned and listed in the report.
+// If ctx is done before that, the tasks still in flight are abandoned, listed in the report and ctx.Err() is returned.
+// The execution context of the abandoned tasks is cancelled, and the tasks that are not updated as failed in time
+// are picked up again by the server once their response timeout expires.
+func (c *TaskRunner) Shutdown(ctx context.Context) (*ShutdownReport, error) {
+	log.Info("Shutting down task runner")
+	c.cancelRunnerContext()
+	done := make(chan struct{})
+	go func() {
+		c.workerWaitGroup.Wait()
+		c.inFlightTasksWaitGroup.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Info("Task runner shut down gracefully")
+		return &ShutdownReport{
+			AbandonedTasks: c.getAbandonedTasks(),
+		}, nil
+	case <-ctx.Done():
+		c.cancelExecutionContext()
+		return &ShutdownReport{
+			AbandonedTasks: append(c.getAbandonedTasks(), c.getInFlightTasks()...),
+		}, ctx.Err()
 	}
-	err = c.increaseMaxAllowedWorkers(taskName, batchSize)
+}
+
+func (c *TaskRunner) isShuttingDown() bool {
+	return c.runnerContext.Err() != nil
+}
+
+func (c *TaskRunner) startWorker(w Worker) error {
+	workerName := getWorkerName(w)
+	batchSize := w.BatchSize()
+	pollInterval := w.PollInterval()
+	if c.isShuttingDown() {
+		return fmt.Errorf("task runner is shutting down, can not start worker for taskName: %s", workerName)
+	}
+	c.setPollInterval(workerName, pollInterval)
+	registered := c.isWorkerRegistered(workerName)
+	err := c.increaseMaxAllowedWorkers(workerName, batchSize)
 	if err != nil {
 		return err
 	}
-	if previousMaxAllowedWorkers < 1 {
+	if registered {
+		c.notifyCapacityChanged(workerName)
+	} else {
+		if isPausedByEnv(w.TaskName()) {
+			c.setPaused(workerName, true)
+			log.Info("Worker for task: ", workerName, " starts paused by environment variable")
+		}
+		c.workerRegistry.register(workerName, w)
 		c.workerWaitGroup.Add(1)
-		go c.pollAndExecute(taskName, executeFunction, taskDomain)
+		go c.pollAndExecute(workerName)
 	}
 	log.Info(
 		fmt.Sprintf(
 			"Started %d worker(s) for taskName %s, polling in interval of %d ms",
 			batchSize,
-			taskName,
+			workerName,
 			pollInterval.Milliseconds(),
 		),
 	)
 	return nil
 }
 
-func (c *TaskRunner) pollAndExecute(taskName string, executeFunction model.ExecuteTaskFunction, domain string) {
+func (c *TaskRunner) pollAndExecute(workerName string) {
 	defer c.workerWaitGroup.Done()
 	defer concurrency.HandlePanicError("poll_and_execute")
-	for c.isWorkerAlive(taskName) {
-		err := c.runBatch(taskName, executeFunction, domain)
+	backoff := &pollBackoff{}
+	rotation := &domainRotation{}
+	for !c.isShuttingDown() {
+		w, ok := c.workerRegistry.get(workerName)
+		if !ok {
+			log.Error("No worker registered for taskName: ", workerName)
+			return
+		}
+		err := c.runBatch(workerName, w, backoff, rotation)
 		if err != nil {
 			log.Error(
 				"Failed to poll and execute",
 				", reason: ", err.Error(),
-				", taskName: ", taskName,
-				", domain: ", domain,
+				", taskName: ", w.TaskName(),
+				", domain: ", w.Domain(),
 			)
 		}
 	}
 }
 
-func (c *TaskRunner) runBatch(taskName string, executeFunction model.ExecuteTaskFunction, domain string) error {
-	batchSize, err := c.getAvailableWorkerAmount(taskName)
+// runBatch Polls the next domain of the worker for as many tasks as it has capacity for, and starts executing them.
+// Waits for the poll interval only once every domain was polled without tasks
+func (c *TaskRunner) runBatch(workerName string, w Worker, backoff *pollBackoff, rotation *domainRotation) error {
+	taskName := w.TaskName()
+	batchSize, err := c.getAvailableWorkerAmount(workerName)
 	if err != nil {
 		return err
 	}
+	if c.IsWorkerPaused(workerName) {
+		metrics.IncrementTaskPaused(taskName)
+		c.waitForCapacity(workerName)
+		return nil
+	}
 	if batchSize < 1 {
-		// TODO wait until there is available workers
-		time.Sleep(batchPollNoAvailableWorkerRetryInterval)
+		c.waitForCapacity(workerName)
 		return nil
 	}
-	tasks, err := c.batchPoll(taskName, batchSize, domain)
+	batchSize = c.acquireRateLimit(taskName, batchSize)
+	if batchSize < 1 {
+		return nil
+	}
+	if c.isShuttingDown() {
+		c.releaseRateLimit(taskName, batchSize)
+		return nil
+	}
+	domains := getDomains(w)
+	domain := rotation.nextDomain(domains)
+	var tasks []model.Task
+	err = c.beforePoll(taskName, domain)
+	if err == nil {
+		if !c.waitForCircuit() {
+			c.releaseRateLimit(taskName, batchSize)
+			return nil
+		}
+		tasks, err = c.batchPoll(taskName, batchSize, domain, c.getWorkerId(w))
+		if err != nil {
+			c.recordPollError(workerName, err)
+		}
+	}
+	c.releaseRateLimit(taskName, batchSize-len(tasks))
 	if err != nil {
-		time.Sleep(batchPollErrorRetryInterval)
+		if c.isShuttingDown() {
+			return nil
+		}
+		backoff.failedPolls += 1
+		c.sleep(c.getErrorBackoffDelay(backoff.failedPolls))
 		return err
 	}
 	if len(tasks) < 1 {
-		log.Debug("No tasks available for: ", taskName)
-		pollInterval, err := c.GetPollIntervalForTask(taskName)
+		log.Debug("No tasks available for: ", WorkerName(taskName, domain))
+		pollInterval, err := c.GetPollIntervalForTask(workerName)
 		if err != nil {
 			return err
 		}
-		time.Sleep(pollInterval)
+		backoff.failedPolls = 0
+		backoff.emptyPolls += 1
+		if backoff.emptyPolls%len(domains) == 0 {
+			c.sleep(c.getEmptyPollBackoffDelay(pollInterval, backoff.emptyPolls/len(domains)))
+		}
 		return nil
 	}
-	c.increaseRunningWorkers(taskName, len(tasks))
+	backoff.reset()
+	c.increaseRunningWorkers(workerName, len(tasks))
 	for _, task := range tasks {
-		go c.executeAndUpdateTask(taskName, task, executeFunction)
+		c.addInFlightTask(task)
+		c.submitTask(workerName, w, task)
 	}
 	return nil
 }
 
-func (c *TaskRunner) executeAndUpdateTask(taskName string, task model.Task, executeFunction model.ExecuteTaskFunction) error {
-	defer c.runningWorkerDone(taskName)
+// sleep waits for the given duration, returning earlier if the runner is shutting down
+func (c *TaskRunner) sleep(duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-c.runnerContext.Done():
+	}
+}
+
+// executeAndUpdateTask Executes the task polled at polledAt, within its deadline counted from the poll, and updates its result
+func (c *TaskRunner) executeAndUpdateTask(workerName string, w Worker, task model.Task, polledAt time.Time) error {
+	taskName := w.TaskName()
+	defer c.inFlightTaskDone(task.TaskId)
+	defer c.runningWorkerDone(workerName)
 	defer concurrency.HandlePanicError("execute_and_update_task")
-	taskResult, err := c.executeTask(&task, executeFunction)
+	var taskResult *model.TaskResult
+	err := c.downloadExternalInput(taskName, &task)
+	traceContext := tracing.Extract(context.Background(), task.InputData)
 	if err != nil {
-		metrics.IncrementTaskExecuteError(
-			taskName, err,
+		log.Error(
+			"Failed to download external input",
+			", reason: ", err.Error(),
+			", taskName: ", taskName,
+			", taskId: ", task.TaskId,
 		)
-		return err
+		taskResult = model.NewTaskResultFromTaskWithError(&task, err)
+	} else {
+		c.startHeartbeat(taskName, &task, polledAt)
+		defer c.stopHeartbeat(task.TaskId)
+		taskResult, err = c.executeTask(traceContext, workerName, &task, polledAt, c.getWorkerId(w), c.aroundExecute(taskName, getExecuteFunction(w)))
+		c.stopHeartbeat(task.TaskId)
+		if err != nil {
+			metrics.IncrementTaskExecuteErrorWithDomain(
+				taskName, task.Domain, err,
+			)
+			return err
+		}
 	}
-	err = c.updateTaskWithRetry(taskName, taskResult)
+	if taskResult.WorkerId == "" {
+		taskResult.WorkerId = task.WorkerId
+	}
+	c.beforeUpdate(&task, taskResult)
+	c.uploadExternalOutput(taskName, taskResult)
+	err = c.updateTaskWithRetry(traceContext, workerName, taskName, taskResult)
+	c.afterUpdate(&task, taskResult, err)
 	return err
 }
 
-func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]model.Task, error) {
-	timeout, err := c.GetPollIntervalForTask(taskName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get poll interval for task %s, reason: %s", taskName, err.Error())
+// batchPoll Polls for up to count tasks.  The poll is not cancelled by shutdown, as the server may already have dequeued
+// the tasks it answers with, and the caller executes them before stopping
+func (c *TaskRunner) batchPoll(taskName string, count int, domain string, workerId string) (tasks []model.Task, err error) {
+	timeout := c.GetPollTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+pollRequestMargin)
+	defer cancel()
+	if tracing.IsEnabled() {
+		var span *tracing.Span
+		ctx, span = tracing.StartSpan(ctx, "poll "+taskName)
+		defer func() {
+			span.SetAttribute("task.count", strconv.Itoa(len(tasks)))
+			span.End(err)
+		}()
 	}
 	var domainOptional optional.String
 	if domain != "" {
@@ -259,28 +611,32 @@ func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]mod
 	}
 	log.Debug(
 		"Polling for task: ", taskName,
+		", domain: ", domain,
+		", workerId: ", workerId,
 		", in batches of size: ", count,
 	)
-	metrics.IncrementTaskPoll(taskName)
+	metrics.IncrementTaskPollWithDomain(taskName, domain)
 	startTime := time.Now()
 	tasks, response, err := c.conductorTaskResourceClient.BatchPoll(
-		context.Background(),
+		ctx,
 		taskName,
 		&client.TaskResourceApiBatchPollOpts{
 			Domain:   domainOptional,
-			Workerid: optional.NewString(hostname),
+			Workerid: optional.NewString(workerId),
 			Count:    optional.NewInt32(int32(count)),
 			Timeout:  optional.NewInt32(int32(timeout.Milliseconds())),
 		},
 	)
 	spentTime := time.Since(startTime)
-	metrics.RecordTaskPollTime(
+	c.recordServerResponse(response, err)
+	metrics.RecordTaskPollTimeWithDomain(
 		taskName,
+		domain,
 		spentTime.Seconds(),
 	)
 	if err != nil {
-		metrics.IncrementTaskPollError(
-			taskName, err,
+		metrics.IncrementTaskPollErrorWithDomain(
+			taskName, domain, err,
 		)
 		return nil, err
 	}
@@ -288,28 +644,56 @@ func (c *TaskRunner) batchPoll(taskName string, count int, domain string) ([]mod
 		return nil, nil
 	}
 	log.Debug(fmt.Sprintf("Polled %d tasks for taskName: %s", len(tasks), taskName))
+	// Set by the server as well, the results built from the tasks are reported by the worker id
+	for i := range tasks {
+		if tasks[i].WorkerId == "" {
+			tasks[i].WorkerId = workerId
+		}
+	}
 	return tasks, nil
 }
 
-func (c *TaskRunner) executeTask(t *model.Task, executeFunction model.ExecuteTaskFunction) (*model.TaskResult, error) {
+// executeTask Executes the task within its deadline, tracing the execution as child of the span within traceContext when tracing is enabled
+func (c *TaskRunner) executeTask(traceContext context.Context, workerName string, t *model.Task, polledAt time.Time, workerId string, executeFunction model.ExecuteTaskFunctionWithContext) (*model.TaskResult, error) {
 	log.Trace(
 		"Executing task of type: ", t.TaskDefName,
 		", taskId: ", t.TaskId,
 		", workflowId: ", t.WorkflowInstanceId,
+		", workerId: ", workerId,
 	)
+	timeout := c.getExecutionTimeout(t)
+	ctx, cancel := c.newExecutionContext(polledAt, timeout)
+	defer cancel()
+	var span *tracing.Span
+	if tracing.IsEnabled() {
+		_, span = tracing.StartSpan(traceContext, "execute "+t.TaskDefName)
+		span.SetAttribute("task.id", t.TaskId)
+		span.SetAttribute("workflow.id", t.WorkflowInstanceId)
+		ctx = tracing.ContextWithSpanContext(ctx, span.SpanContext())
+	}
+	taskLogger := newTaskLogger(t, workerId)
+	ctx = withTaskLogger(ctx, taskLogger)
+	stopTaskLogFlush := c.startTaskLogFlush(t.TaskDefName, taskLogger)
 	startTime := time.Now()
-	taskExecutionOutput, err := executeFunction(t)
+	taskExecutionOutput, err := executeWithinContext(ctx, timeout, t, executeFunction)
 	spentTime := time.Since(startTime)
-	metrics.RecordTaskExecuteTime(
-		t.TaskDefName, float64(spentTime.Milliseconds()),
-	)
-	if err != nil {
-		return model.NewTaskResultFromTaskWithError(t, err), nil
+	if executionContextError, ok := err.(*ExecutionContextError); ok && executionContextError.returned != nil {
+		c.trackAbandonedExecution(workerName, t, executionContextError.returned)
 	}
-
-	taskResult, err := model.GetTaskResultFromTaskExecutionOutput(t, taskExecutionOutput)
-	if err != nil {
-		return model.NewTaskResultFromTaskWithError(t, err), nil
+	stopTaskLogFlush()
+	metrics.RecordTaskExecuteTimeWithDomain(
+		t.TaskDefName, t.Domain, float64(spentTime.Milliseconds()),
+	)
+	c.recordExecuteTime(t.TaskDefName, spentTime)
+	taskResult := c.getTaskResultFromExecution(t, taskExecutionOutput, err)
+	taskResult.Logs = append(taskResult.Logs, taskLogger.drain()...)
+	if span != nil {
+		span.SetAttribute("task.status", string(taskResult.Status))
+		if taskResult.Status == model.FailedTask || taskResult.Status == model.FailedWithTerminalErrorTask {
+			span.End(errors.New(taskResult.ReasonForIncompletion))
+		} else {
+			span.End(nil)
+		}
 	}
 	log.Trace(
 		"Executed task of type: ", t.TaskDefName,
@@ -319,14 +703,54 @@ func (c *TaskRunner) executeTask(t *model.Task, executeFunction model.ExecuteTas
 	return taskResult, nil
 }
 
-func (c *TaskRunner) updateTaskWithRetry(taskName string, taskResult *model.TaskResult) error {
+func (c *TaskRunner) getTaskResultFromExecution(t *model.Task, taskExecutionOutput interface{}, err error) *model.TaskResult {
+	if panicError, ok := err.(*PanicError); ok {
+		metrics.IncrementTaskExecutePanic(t.TaskDefName)
+		log.Error(
+			"Task execution function panicked",
+			", taskType: ", t.TaskDefName,
+			", taskId: ", t.TaskId,
+			", workflowId: ", t.WorkflowInstanceId,
+			", reason: ", panicError.Error(),
+		)
+		return newTaskResultFromPanic(t, panicError, c.GetPanicPolicy())
+	}
+	if executionContextError, ok := err.(*ExecutionContextError); ok {
+		log.Warning(
+			"Task execution did not complete",
+			", taskType: ", t.TaskDefName,
+			", taskId: ", t.TaskId,
+			", workflowId: ", t.WorkflowInstanceId,
+			", reason: ", executionContextError.Error(),
+		)
+	}
+	if err != nil {
+		return model.NewTaskResultFromTaskWithError(t, err)
+	}
+	taskResult, err := model.GetTaskResultFromTaskExecutionOutput(t, taskExecutionOutput)
+	if err != nil {
+		return model.NewTaskResultFromTaskWithError(t, err)
+	}
+	return taskResult
+}
+
+func (c *TaskRunner) updateTaskWithRetry(ctx context.Context, workerName string, taskName string, taskResult *model.TaskResult) (err error) {
+	if tracing.IsEnabled() {
+		var span *tracing.Span
+		ctx, span = tracing.StartSpan(ctx, "update "+taskName)
+		span.SetAttribute("task.id", taskResult.TaskId)
+		defer func() {
+			span.End(err)
+		}()
+	}
 	log.Debug(
 		"Updating task of type: ", taskName,
 		", taskId: ", taskResult.TaskId,
 		", workflowId: ", taskResult.WorkflowInstanceId,
 	)
+	var response *http.Response
 	for attempt := 0; attempt < taskUpdateRetryAttemptsLimit; attempt += 1 {
-		response, err := c.updateTask(taskName, taskResult)
+		response, err = c.updateTask(ctx, taskName, taskResult)
 		if err == nil {
 			log.Debug(
 				"Updated task of type: ", taskName,
@@ -336,28 +760,90 @@ func (c *TaskRunner) updateTaskWithRetry(taskName string, taskResult *model.Task
 			return nil
 		}
 		metrics.IncrementTaskUpdateError(taskName, err)
+		c.recordUpdateError(workerName, err)
 		log.Debug(
 			"Failed to update task",
 			", reason: ", err.Error(),
 			", task type: ", taskName,
 			", taskId: ", taskResult.TaskId,
 			", workflowId: ", taskResult.WorkflowInstanceId,
-			", response: ", *response,
+			", response: ", response,
 		)
 		amount := (1 << attempt)
 		time.Sleep(time.Duration(amount) * time.Second)
 	}
+	outbox := c.getTaskResultOutbox()
+	if outbox != nil && !isRejectedByServer(response) {
+		outboxErr := outbox.Add(taskName, taskResult)
+		if outboxErr == nil {
+			log.Warning(
+				"Stored task result in outbox after failing to update it",
+				", reason: ", err.Error(),
+				", task type: ", taskName,
+				", taskId: ", taskResult.TaskId,
+				", workflowId: ", taskResult.WorkflowInstanceId,
+			)
+			return nil
+		}
+		log.Error("Failed to store task result in outbox, reason: ", outboxErr.Error())
+	}
 	return fmt.Errorf("failed to update task %s after %d attempts", taskName, taskUpdateRetryAttemptsLimit)
 }
 
-func (c *TaskRunner) updateTask(taskName string, taskResult *model.TaskResult) (*http.Response, error) {
+func (c *TaskRunner) updateTask(ctx context.Context, taskName string, taskResult *model.TaskResult) (*http.Response, error) {
 	startTime := time.Now()
-	_, response, err := c.conductorTaskResourceClient.UpdateTask(context.Background(), taskResult)
+	_, response, err := c.conductorTaskResourceClient.UpdateTask(ctx, taskResult)
 	spentTime := time.Since(startTime).Milliseconds()
+	c.recordServerResponse(response, err)
 	metrics.RecordTaskUpdateTime(taskName, float64(spentTime))
 	return response, err
 }
 
+func (c *TaskRunner) recordPollError(taskName string, err error) {
+	c.workerErrorsMutex.Lock()
+	defer c.workerErrorsMutex.Unlock()
+	c.lastPollErrorByTaskName[taskName] = &WorkerError{Message: err.Error(), Time: time.Now()}
+}
+
+func (c *TaskRunner) recordUpdateError(taskName string, err error) {
+	c.workerErrorsMutex.Lock()
+	defer c.workerErrorsMutex.Unlock()
+	c.lastUpdateErrorByTaskName[taskName] = &WorkerError{Message: err.Error(), Time: time.Now()}
+}
+
+func (c *TaskRunner) getLastPollError(taskName string) *WorkerError {
+	c.workerErrorsMutex.RLock()
+	defer c.workerErrorsMutex.RUnlock()
+	return c.lastPollErrorByTaskName[taskName]
+}
+
+func (c *TaskRunner) getLastUpdateError(taskName string) *WorkerError {
+	c.workerErrorsMutex.RLock()
+	defer c.workerErrorsMutex.RUnlock()
+	return c.lastUpdateErrorByTaskName[taskName]
+}
+
+// recordExecuteTime Keeps an exponentially weighted moving average of the execution time, favouring recent executions.
+// Kept by task name, shared by the workers of every domain
+func (c *TaskRunner) recordExecuteTime(taskName string, spentTime time.Duration) {
+	c.averageExecuteTimeByTaskNameMutex.Lock()
+	defer c.averageExecuteTimeByTaskNameMutex.Unlock()
+	average, ok := c.averageExecuteTimeByTaskName[taskName]
+	if !ok {
+		c.averageExecuteTimeByTaskName[taskName] = spentTime
+		return
+	}
+	c.averageExecuteTimeByTaskName[taskName] = time.Duration(
+		(1-executeTimeSmoothingFactor)*float64(average) + executeTimeSmoothingFactor*float64(spentTime),
+	)
+}
+
+func (c *TaskRunner) getAverageExecuteTime(taskName string) time.Duration {
+	c.averageExecuteTimeByTaskNameMutex.RLock()
+	defer c.averageExecuteTimeByTaskNameMutex.RUnlock()
+	return c.averageExecuteTimeByTaskName[taskName]
+}
+
 func (c *TaskRunner) getAvailableWorkerAmount(taskName string) (int, error) {
 	allowed, err := c.getMaxAllowedWorkers(taskName)
 	if err != nil {
@@ -390,11 +876,12 @@ func (c *TaskRunner) getRunningWorkers(taskName string) (int, error) {
 	return amount, nil
 }
 
-func (c *TaskRunner) isWorkerAlive(taskName string) bool {
+// isWorkerRegistered Whether a worker was started for the task, its polling goroutine keeps running with a batch size of 0
+func (c *TaskRunner) isWorkerRegistered(taskName string) bool {
 	c.batchSizeByTaskNameMutex.RLock()
 	defer c.batchSizeByTaskNameMutex.RUnlock()
-	allowed, ok := c.batchSizeByTaskName[taskName]
-	return ok && allowed > 0
+	_, ok := c.batchSizeByTaskName[taskName]
+	return ok
 }
 
 func (c *TaskRunner) increaseRunningWorkers(taskName string, amount int) error {
@@ -410,9 +897,56 @@ func (c *TaskRunner) runningWorkerDone(taskName string) error {
 	defer c.runningWorkersByTaskNameMutex.Unlock()
 	c.runningWorkersByTaskName[taskName] -= 1
 	log.Trace("Running worker done for task: ", taskName)
+	c.notifyCapacityChanged(taskName)
 	return nil
 }
 
+func (c *TaskRunner) addInFlightTask(task model.Task) {
+	c.inFlightTasksWaitGroup.Add(1)
+	c.inFlightTasksMutex.Lock()
+	defer c.inFlightTasksMutex.Unlock()
+	c.inFlightTasks[task.TaskId] = task
+}
+
+func (c *TaskRunner) inFlightTaskDone(taskId string) {
+	c.inFlightTasksMutex.Lock()
+	delete(c.inFlightTasks, taskId)
+	c.inFlightTasksMutex.Unlock()
+	c.inFlightTasksWaitGroup.Done()
+}
+
+// abandonTask Gives up on a polled task that was not started, leaving it to the server to schedule again once
+// its response timeout expires.  The task is listed in the shutdown report
+func (c *TaskRunner) abandonTask(workerName string, task model.Task) {
+	log.Warning(
+		"Abandoning task polled during shutdown",
+		", taskType: ", task.TaskDefName,
+		", taskId: ", task.TaskId,
+		", workflowId: ", task.WorkflowInstanceId,
+	)
+	c.inFlightTasksMutex.Lock()
+	c.abandonedTasks = append(c.abandonedTasks, task)
+	c.inFlightTasksMutex.Unlock()
+	c.runningWorkerDone(workerName)
+	c.inFlightTaskDone(task.TaskId)
+}
+
+func (c *TaskRunner) getAbandonedTasks() []model.Task {
+	c.inFlightTasksMutex.RLock()
+	defer c.inFlightTasksMutex.RUnlock()
+	return append([]model.Task(nil), c.abandonedTasks...)
+}
+
+func (c *TaskRunner) getInFlightTasks() []model.Task {
+	c.inFlightTasksMutex.RLock()
+	defer c.inFlightTasksMutex.RUnlock()
+	tasks := make([]model.Task, 0, len(c.inFlightTasks))
+	for _, task := range c.inFlightTasks {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
 func (c *TaskRunner) increaseMaxAllowedWorkers(taskName string, batchSize int) error {
 	c.batchSizeByTaskNameMutex.Lock()
 	defer c.batchSizeByTaskNameMutex.Unlock()
@@ -422,6 +956,10 @@ func (c *TaskRunner) increaseMaxAllowedWorkers(taskName string, batchSize int) e
 }
 
 func (c *TaskRunner) SetPollIntervalForTask(taskName string, pollInterval time.Duration) error {
+	return c.setPollInterval(c.workerRegistry.resolve(taskName), pollInterval)
+}
+
+func (c *TaskRunner) setPollInterval(taskName string, pollInterval time.Duration) error {
 	c.pollIntervalByTaskNameMutex.Lock()
 	defer c.pollIntervalByTaskNameMutex.Unlock()
 	c.pollIntervalByTaskName[taskName] = pollInterval
@@ -430,6 +968,7 @@ func (c *TaskRunner) SetPollIntervalForTask(taskName string, pollInterval time.D
 }
 
 func (c *TaskRunner) GetPollIntervalForTask(taskName string) (pollInterval time.Duration, err error) {
+	taskName = c.workerRegistry.resolve(taskName)
 	c.pollIntervalByTaskNameMutex.RLock()
 	defer c.pollIntervalByTaskNameMutex.RUnlock()
 	pollInterval, ok := c.pollIntervalByTaskName[taskName]
@@ -439,6 +978,20 @@ func (c *TaskRunner) GetPollIntervalForTask(taskName string) (pollInterval time.
 	return pollInterval, nil
 }
 
+// SetPanicPolicy Sets how a panic within a task execution function is reported to the server.
+// Defaults to ReportPanicAsFailed
+func (c *TaskRunner) SetPanicPolicy(panicPolicy PanicPolicy) {
+	c.panicPolicyMutex.Lock()
+	defer c.panicPolicyMutex.Unlock()
+	c.panicPolicy = panicPolicy
+}
+
+func (c *TaskRunner) GetPanicPolicy() PanicPolicy {
+	c.panicPolicyMutex.RLock()
+	defer c.panicPolicyMutex.RUnlock()
+	return c.panicPolicy
+}
+
 func (c *TaskRunner) GetBatchSizeForAll() (batchSizeByTaskName map[string]int) {
 	c.batchSizeByTaskNameMutex.RLock()
 	defer c.batchSizeByTaskNameMutex.RUnlock()
@@ -450,6 +1003,7 @@ func (c *TaskRunner) GetBatchSizeForAll() (batchSizeByTaskName map[string]int) {
 }
 
 func (c *TaskRunner) GetBatchSizeForTask(taskName string) (batchSize int) {
+	taskName = c.workerRegistry.resolve(taskName)
 	c.batchSizeByTaskNameMutex.RLock()
 	defer c.batchSizeByTaskNameMutex.RUnlock()
 	batchSize, ok := c.batchSizeByTaskName[taskName]
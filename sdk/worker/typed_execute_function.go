@@ -0,0 +1,301 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// DecodingMode Defines how the task input is decoded into the input struct of a typed execute function
+type DecodingMode string
+
+const (
+	//StrictDecoding Fails on input keys without a matching field and on values of the wrong type
+	StrictDecoding DecodingMode = "STRICT"
+	//LenientDecoding Ignores input keys without a matching field and converts scalar values between strings, numbers and booleans
+	LenientDecoding DecodingMode = "LENIENT"
+)
+
+var (
+	taskPointerType = reflect.TypeOf(&model.Task{})
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// FieldError Failure to decode a single input field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// InputDecodingError Failure to decode the task input, with one entry per offending field
+type InputDecodingError struct {
+	FieldErrors []FieldError
+}
+
+func (e *InputDecodingError) Error() string {
+	messages := make([]string, len(e.FieldErrors))
+	for i, fieldError := range e.FieldErrors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message)
+	}
+	return "failed to decode task input, " + strings.Join(messages, "; ")
+}
+
+// NewTypedExecuteFunction Adapts a function with a user defined input struct into an ExecuteTaskFunction.
+// Supported signatures, where Input is a struct or pointer to struct and Output any JSON serializable type:
+//   - func(input Input) (Output, error)
+//   - func(t *model.Task, input Input) (Output, error)
+//
+// The task input is decoded into Input according to the mode.  When decoding fails the task is reported as
+// FAILED_WITH_TERMINAL_ERROR, with the field level messages as reason for incompletion
+func NewTypedExecuteFunction(fn interface{}, mode DecodingMode) (model.ExecuteTaskFunction, error) {
+	fnValue := reflect.ValueOf(fn)
+	inputType, withTask, err := validateTypedExecuteFunction(fnValue)
+	if err != nil {
+		return nil, err
+	}
+	return func(t *model.Task) (interface{}, error) {
+		input := reflect.New(inputType)
+		err := DecodeTaskInput(t, input.Interface(), mode)
+		if err != nil {
+			taskResult := model.NewTaskResultFromTaskWithError(t, err)
+			taskResult.Status = model.FailedWithTerminalErrorTask
+			return taskResult, nil
+		}
+		arguments := []reflect.Value{input}
+		if fnValue.Type().In(fnValue.Type().NumIn()-1).Kind() != reflect.Ptr {
+			arguments[0] = input.Elem()
+		}
+		if withTask {
+			arguments = append([]reflect.Value{reflect.ValueOf(t)}, arguments...)
+		}
+		results := fnValue.Call(arguments)
+		if !results[1].IsNil() {
+			return nil, results[1].Interface().(error)
+		}
+		return results[0].Interface(), nil
+	}, nil
+}
+
+// DecodeTaskInput Decodes the input data of the task into the struct pointed by input, according to the mode
+func DecodeTaskInput(t *model.Task, input interface{}, mode DecodingMode) error {
+	inputValue := reflect.ValueOf(input)
+	if inputValue.Kind() != reflect.Ptr || inputValue.IsNil() || inputValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("input must be a non nil pointer to struct, got: %T", input)
+	}
+	decodingError := &InputDecodingError{}
+	knownFields := make(map[string]bool)
+	decodeStruct(t.InputData, inputValue.Elem(), mode, knownFields, decodingError)
+	if mode == StrictDecoding {
+		unknownFields := make([]string, 0)
+		for key := range t.InputData {
+			if !knownFields[key] {
+				unknownFields = append(unknownFields, key)
+			}
+		}
+		sort.Strings(unknownFields)
+		for _, key := range unknownFields {
+			decodingError.FieldErrors = append(decodingError.FieldErrors, FieldError{
+				Field:   key,
+				Message: "unknown field",
+			})
+		}
+	}
+	if len(decodingError.FieldErrors) > 0 {
+		return decodingError
+	}
+	return nil
+}
+
+func validateTypedExecuteFunction(fnValue reflect.Value) (inputType reflect.Type, withTask bool, err error) {
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, false, fmt.Errorf("typed execute function must be a function, got: %s", fnValue.Kind())
+	}
+	fnType := fnValue.Type()
+	if fnType.NumOut() != 2 || fnType.Out(1) != errorType {
+		return nil, false, fmt.Errorf("typed execute function must return (Output, error), got: %s", fnType)
+	}
+	switch fnType.NumIn() {
+	case 1:
+	case 2:
+		if fnType.In(0) != taskPointerType {
+			return nil, false, fmt.Errorf("first argument of typed execute function must be *model.Task, got: %s", fnType.In(0))
+		}
+		withTask = true
+	default:
+		return nil, false, fmt.Errorf("typed execute function must receive (Input) or (*model.Task, Input), got: %s", fnType)
+	}
+	inputType = fnType.In(fnType.NumIn() - 1)
+	if inputType.Kind() == reflect.Ptr {
+		inputType = inputType.Elem()
+	}
+	if inputType.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("input of typed execute function must be a struct or pointer to struct, got: %s", inputType)
+	}
+	return inputType, withTask, nil
+}
+
+// decodeStruct Decodes the input into the fields of the struct, following the field matching of encoding/json.
+// Returns whether any field was decoded
+func decodeStruct(inputData map[string]interface{}, structValue reflect.Value, mode DecodingMode, knownFields map[string]bool, decodingError *InputDecodingError) bool {
+	decoded := false
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				if decodeEmbeddedStruct(inputData, field, structValue.Field(i), mode, knownFields, decodingError) {
+					decoded = true
+				}
+				continue
+			}
+		}
+		// Unexported fields can not be set, including embedded ones of non struct types
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		key, value, ok := lookupInputKey(inputData, name)
+		if !ok {
+			knownFields[name] = true
+			continue
+		}
+		knownFields[key] = true
+		if value == nil {
+			continue
+		}
+		err := decodeField(value, structValue.Field(i), mode)
+		if err != nil {
+			decodingError.FieldErrors = append(decodingError.FieldErrors, FieldError{
+				Field:   key,
+				Message: err.Error(),
+			})
+			continue
+		}
+		decoded = true
+	}
+	return decoded
+}
+
+// decodeEmbeddedStruct Decodes the input into the promoted fields of an embedded struct or pointer to struct.
+// Nil pointers are only allocated when one of their fields is decoded, and left alone when unexported
+func decodeEmbeddedStruct(inputData map[string]interface{}, field reflect.StructField, fieldValue reflect.Value, mode DecodingMode, knownFields map[string]bool, decodingError *InputDecodingError) bool {
+	if field.Type.Kind() != reflect.Ptr {
+		return decodeStruct(inputData, fieldValue, mode, knownFields, decodingError)
+	}
+	if !fieldValue.IsNil() {
+		return decodeStruct(inputData, fieldValue.Elem(), mode, knownFields, decodingError)
+	}
+	if field.PkgPath != "" {
+		return false
+	}
+	embedded := reflect.New(field.Type.Elem())
+	if !decodeStruct(inputData, embedded.Elem(), mode, knownFields, decodingError) {
+		return false
+	}
+	fieldValue.Set(embedded)
+	return true
+}
+
+// lookupInputKey Finds the input key of a field, preferring an exact match over a case insensitive one like encoding/json
+func lookupInputKey(inputData map[string]interface{}, name string) (string, interface{}, bool) {
+	if value, ok := inputData[name]; ok {
+		return name, value, true
+	}
+	keys := make([]string, 0, len(inputData))
+	for key := range inputData {
+		if strings.EqualFold(key, name) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", nil, false
+	}
+	sort.Strings(keys)
+	return keys[0], inputData[keys[0]], true
+}
+
+func jsonFieldName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+func decodeField(value interface{}, fieldValue reflect.Value, mode DecodingMode) error {
+	err := decodeValue(value, fieldValue)
+	if err == nil || mode != LenientDecoding {
+		return err
+	}
+	converted, ok := convertScalar(value, fieldValue.Kind())
+	if !ok {
+		return err
+	}
+	return decodeValue(converted, fieldValue)
+}
+
+func decodeValue(value interface{}, fieldValue reflect.Value) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	target := reflect.New(fieldValue.Type())
+	err = json.Unmarshal(data, target.Interface())
+	if err != nil {
+		if typeError, ok := err.(*json.UnmarshalTypeError); ok {
+			if typeError.Field != "" {
+				return fmt.Errorf("expected %s at %s, got %s", typeError.Type, typeError.Field, typeError.Value)
+			}
+			return fmt.Errorf("expected %s, got %s", typeError.Type, typeError.Value)
+		}
+		return err
+	}
+	fieldValue.Set(target.Elem())
+	return nil
+}
+
+// convertScalar Converts between strings, numbers and booleans for the lenient decoding mode
+func convertScalar(value interface{}, kind reflect.Kind) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		switch kind {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			var converted interface{}
+			err := json.Unmarshal([]byte(strings.TrimSpace(v)), &converted)
+			if err != nil {
+				return nil, false
+			}
+			return converted, true
+		}
+	case float64, bool:
+		if kind == reflect.String {
+			return fmt.Sprint(v), true
+		}
+	}
+	return nil, false
+}
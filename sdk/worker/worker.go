@@ -0,0 +1,150 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Worker Declarative definition of a task worker.  Workers are started with TaskRunner.StartWorkers,
+// which owns their lifecycle from then on
+type Worker interface {
+	//TaskName Task name to poll and execute the work
+	TaskName() string
+	//Execute Task execution function
+	Execute(t *model.Task) (interface{}, error)
+	//BatchSize Amount of tasks to be polled. Each polled task will be executed and updated within its own unique goroutine
+	BatchSize() int
+	//PollInterval Time to wait for between polls if there are no tasks available
+	PollInterval() time.Duration
+	//Domain Task domain. Optional for polling, empty string polls without domain
+	Domain() string
+	//Identity Worker id reported to the server.  Optional, defaults to the id chosen by the WorkerIdStrategy of the TaskRunner when empty
+	Identity() string
+}
+
+// ContextWorker Worker whose execution receives a context, done when the execution deadline is reached
+// or the runner gives up on the task during shutdown.  When implemented, ExecuteWithContext is used instead of Execute
+type ContextWorker interface {
+	Worker
+	ExecuteWithContext(ctx context.Context, t *model.Task) (interface{}, error)
+}
+
+// WorkerDescription Snapshot of a worker registered in the TaskRunner
+type WorkerDescription struct {
+	// Name Name of the worker in the TaskRunner, see WorkerName
+	Name         string
+	TaskName     string
+	Domain       string
+	Domains      []string
+	Identity     string
+	BatchSize    int
+	PollInterval time.Duration
+	RunningTasks int
+	Paused       bool
+	// LastPollError Last failure to poll for the task, nil if none
+	LastPollError *WorkerError
+	// LastUpdateError Last failure to update a result of the worker, nil if none
+	LastUpdateError *WorkerError
+}
+
+// WorkerError Failure of a worker operation and when it happened
+type WorkerError struct {
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// functionWorker Worker backed by an ExecuteTaskFunction, used for the workers started with StartWorker
+type functionWorker struct {
+	taskName        string
+	executeFunction model.ExecuteTaskFunctionWithContext
+	batchSize       int
+	pollInterval    time.Duration
+	domains         []string
+	identity        string
+}
+
+func newFunctionWorker(taskName string, executeFunction model.ExecuteTaskFunctionWithContext, batchSize int, pollInterval time.Duration, domains ...string) *functionWorker {
+	return &functionWorker{
+		taskName:        taskName,
+		executeFunction: executeFunction,
+		batchSize:       batchSize,
+		pollInterval:    pollInterval,
+		domains:         domains,
+	}
+}
+
+func (w *functionWorker) TaskName() string {
+	return w.taskName
+}
+
+func (w *functionWorker) Execute(t *model.Task) (interface{}, error) {
+	return w.executeFunction(context.Background(), t)
+}
+
+func (w *functionWorker) ExecuteWithContext(ctx context.Context, t *model.Task) (interface{}, error) {
+	return w.executeFunction(ctx, t)
+}
+
+func (w *functionWorker) BatchSize() int {
+	return w.batchSize
+}
+
+func (w *functionWorker) PollInterval() time.Duration {
+	return w.pollInterval
+}
+
+func (w *functionWorker) Domain() string {
+	return strings.Join(w.domains, ",")
+}
+
+func (w *functionWorker) Domains() []string {
+	return w.domains
+}
+
+func (w *functionWorker) Identity() string {
+	return w.identity
+}
+
+func getExecuteFunction(w Worker) model.ExecuteTaskFunctionWithContext {
+	if contextWorker, ok := w.(ContextWorker); ok {
+		return contextWorker.ExecuteWithContext
+	}
+	return model.NewExecuteTaskFunctionWithContext(w.Execute)
+}
+
+func validateWorker(w Worker) error {
+	if w == nil {
+		return fmt.Errorf("worker can not be nil")
+	}
+	if w.TaskName() == "" {
+		return fmt.Errorf("worker taskName can not be empty")
+	}
+	if w.BatchSize() < 1 {
+		return fmt.Errorf("batchSize value must be positive, taskName: %s", w.TaskName())
+	}
+	if w.PollInterval() < 0 {
+		return fmt.Errorf("pollInterval can not be negative, taskName: %s", w.TaskName())
+	}
+	domains := getDomains(w)
+	for i, domain := range domains {
+		for _, other := range domains[i+1:] {
+			if domain == other {
+				return fmt.Errorf("domain %q is repeated, taskName: %s", domain, w.TaskName())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// PodNameEnv Environment variable commonly set to the pod name through the Kubernetes downward API
+const PodNameEnv = "POD_NAME"
+
+// WorkerIdStrategy Chooses the worker id reported to the server when polling, updating and logging tasks.
+// Workers with an Identity report it instead
+type WorkerIdStrategy interface {
+	WorkerId(taskName string) string
+}
+
+// WorkerIdStrategyFunc Adapts a function into a WorkerIdStrategy
+type WorkerIdStrategyFunc func(taskName string) string
+
+func (f WorkerIdStrategyFunc) WorkerId(taskName string) string {
+	return f(taskName)
+}
+
+// HostnameWorkerId Reports the hostname, the default strategy
+func HostnameWorkerId() WorkerIdStrategy {
+	return StaticWorkerId(model.GetHostname())
+}
+
+// HostnamePidWorkerId Reports the hostname and process id, e.g. host-1234, telling apart processes on the same host
+func HostnamePidWorkerId() WorkerIdStrategy {
+	return StaticWorkerId(fmt.Sprintf("%s-%d", model.GetHostname(), os.Getpid()))
+}
+
+// EnvWorkerId Reports the value of the environment variable, such as PodNameEnv, or the hostname when it is not set
+func EnvWorkerId(name string) WorkerIdStrategy {
+	if workerId := os.Getenv(name); workerId != "" {
+		return StaticWorkerId(workerId)
+	}
+	return HostnameWorkerId()
+}
+
+// StaticWorkerId Reports the same worker id for every task
+func StaticWorkerId(workerId string) WorkerIdStrategy {
+	return WorkerIdStrategyFunc(func(taskName string) string {
+		return workerId
+	})
+}
+
+// SetWorkerIdStrategy Sets how the worker id is chosen for the workers without an Identity.  Defaults to HostnameWorkerId
+func (c *TaskRunner) SetWorkerIdStrategy(strategy WorkerIdStrategy) error {
+	if strategy == nil {
+		return fmt.Errorf("worker id strategy can not be nil")
+	}
+	c.workerIdStrategyMutex.Lock()
+	defer c.workerIdStrategyMutex.Unlock()
+	c.workerIdStrategy = strategy
+	return nil
+}
+
+// getWorkerId Returns the identity of the worker, or the id chosen by the strategy when it has none
+func (c *TaskRunner) getWorkerId(w Worker) string {
+	if identity := w.Identity(); identity != "" {
+		return identity
+	}
+	c.workerIdStrategyMutex.RLock()
+	defer c.workerIdStrategyMutex.RUnlock()
+	return c.workerIdStrategy.WorkerId(w.TaskName())
+}
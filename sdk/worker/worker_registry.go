@@ -0,0 +1,73 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"sort"
+	"sync"
+)
+
+// workerRegistry Workers known by the TaskRunner, by worker name
+type workerRegistry struct {
+	mutex        sync.RWMutex
+	workerByName map[string]Worker
+}
+
+func newWorkerRegistry() *workerRegistry {
+	return &workerRegistry{
+		workerByName: make(map[string]Worker),
+	}
+}
+
+func (r *workerRegistry) register(name string, w Worker) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.workerByName[name] = w
+}
+
+func (r *workerRegistry) get(name string) (Worker, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	w, ok := r.workerByName[name]
+	return w, ok
+}
+
+// resolve Name of the worker addressed by name: the worker registered with that name, otherwise the only worker
+// polling the task of that name in a domain, so that workers started with a single domain are still addressed by their task name
+func (r *workerRegistry) resolve(name string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if _, ok := r.workerByName[name]; ok {
+		return name
+	}
+	resolved := name
+	matches := 0
+	for workerName, w := range r.workerByName {
+		if w.TaskName() == name && len(getDomains(w)) == 1 {
+			resolved = workerName
+			matches += 1
+		}
+	}
+	if matches != 1 {
+		return name
+	}
+	return resolved
+}
+
+func (r *workerRegistry) names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.workerByName))
+	for name := range r.workerByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
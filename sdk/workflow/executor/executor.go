@@ -11,6 +11,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/client"
 	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -49,20 +51,14 @@ func NewWorkflowExecutor(apiClient *client.APIClient) *WorkflowExecutor {
 }
 
 //RegisterWorkflow Registers the workflow on the server.  Overwrites if the flag is set.  If the 'overwrite' flag is not set
-//and the workflow definition differs from the one on the server, the call will fail with response code 409
+//and the workflow definition differs from the one on the server, the call will fail with a client.ConflictError
 func (e *WorkflowExecutor) RegisterWorkflow(overwrite bool, workflow *model.WorkflowDef) error {
-	response, err := e.metadataClient.RegisterWorkflowDef(
+	_, err := e.metadataClient.RegisterWorkflowDef(
 		context.Background(),
 		overwrite,
 		*workflow,
 	)
-	if err != nil {
-		return err
-	}
-	if response.StatusCode > 299 {
-		return fmt.Errorf(response.Status)
-	}
-	return nil
+	return err
 }
 
 //MonitorExecution monitors the workflow execution
@@ -73,11 +69,22 @@ func (e *WorkflowExecutor) MonitorExecution(workflowId string) (workflowMonitor
 }
 
 //StartWorkflow Start workflows
-//Returns the id of the newly created workflow
+//Returns the id of the newly created workflow.  The trace context is added to the workflow input as traceparent
 func (e *WorkflowExecutor) StartWorkflow(startWorkflowRequest *model.StartWorkflowRequest) (workflowId string, err error) {
+	ctx := context.Background()
+	if tracing.IsEnabled() {
+		var span *tracing.Span
+		ctx, span = startWorkflowSpan(ctx, startWorkflowRequest.Name)
+		defer func() {
+			span.SetAttribute("workflow.id", workflowId)
+			span.End(err)
+		}()
+	}
+	request := *startWorkflowRequest
+	request.Input = injectTraceContext(ctx, request.Input)
 	id, _, err := e.workflowClient.StartWorkflowWithRequest(
-		context.Background(),
-		*startWorkflowRequest,
+		ctx,
+		request,
 	)
 	if err != nil {
 		return "", err
@@ -123,26 +130,32 @@ func WaitForWorkflowCompletionUntilTimeout(executionChannel WorkflowExecutionCha
 //GetWorkflow Get workflow execution by workflow Id.  If includeTasks is set, also fetches all the task details.
 //Returns nil if no workflow is found by the id
 func (e *WorkflowExecutor) GetWorkflow(workflowId string, includeTasks bool) (*model.Workflow, error) {
-	workflow, response, err := e.workflowClient.GetExecutionStatus(
+	workflow, _, err := e.workflowClient.GetExecutionStatus(
 		context.Background(),
 		workflowId,
 		&client.WorkflowResourceApiGetExecutionStatusOpts{
 			IncludeTasks: optional.NewBool(includeTasks)},
 	)
-	if response.StatusCode == 404 {
+	if errors.Is(err, client.ErrNotFound) {
 		return nil, nil
 	}
-	return &workflow, err
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
 }
 
 //GetWorkflowStatus Get the status of the workflow execution.
 //This is a lightweight method that returns only overall state of the workflow
 func (e *WorkflowExecutor) GetWorkflowStatus(workflowId string, includeOutput bool, includeVariables bool) (*model.WorkflowState, error) {
-	state, response, err := e.workflowClient.GetWorkflowState(context.Background(), workflowId, includeOutput, includeVariables)
-	if response.StatusCode == 404 {
+	state, _, err := e.workflowClient.GetWorkflowState(context.Background(), workflowId, includeOutput, includeVariables)
+	if errors.Is(err, client.ErrNotFound) {
 		return nil, nil
 	}
-	return &state, err
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
 }
 
 //GetByCorrelationIds Given the list of correlation ids, find and return workflows
@@ -300,25 +313,19 @@ func (e *WorkflowExecutor) UpdateTaskByRefName(taskRefName string, workflowInsta
 	if err != nil {
 		return err
 	}
-	_, response, err := e.taskClient.UpdateTaskByRefName(context.Background(), outputData, workflowInstanceId, taskRefName, string(status))
-	if err != nil {
-		return err
-	}
-	if response.StatusCode == 404 {
-		return fmt.Errorf(response.Status)
-	}
-	return nil
+	_, _, err = e.taskClient.UpdateTaskByRefName(context.Background(), outputData, workflowInstanceId, taskRefName, string(status))
+	return err
 }
 
 //GetTask by task Id returns nil if no such task is found by the id
 func (e *WorkflowExecutor) GetTask(taskId string) (task *model.Task, err error) {
-	t, response, err := e.taskClient.GetTask(context.Background(), taskId)
+	t, _, err := e.taskClient.GetTask(context.Background(), taskId)
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode == 404 {
-		return nil, nil
-	}
 	return &t, nil
 }
 
@@ -339,11 +346,20 @@ func getTaskResultFromOutput(taskId string, workflowInstanceId string, taskExecu
 // ExecuteWorkflow Executes a workflow
 // Returns workflow Id for the newly started workflow
 func (e *WorkflowExecutor) executeWorkflow(workflow *model.WorkflowDef, request *model.StartWorkflowRequest) (workflowId string, err error) {
+	ctx := context.Background()
+	if tracing.IsEnabled() {
+		var span *tracing.Span
+		ctx, span = startWorkflowSpan(ctx, request.Name)
+		defer func() {
+			span.SetAttribute("workflow.id", workflowId)
+			span.End(err)
+		}()
+	}
 	startWorkflowRequest := model.StartWorkflowRequest{
 		Name:                            request.Name,
 		Version:                         request.Version,
 		CorrelationId:                   request.CorrelationId,
-		Input:                           request.Input,
+		Input:                           injectTraceContext(ctx, request.Input),
 		TaskToDomain:                    request.TaskToDomain,
 		ExternalInputPayloadStoragePath: request.ExternalInputPayloadStoragePath,
 		Priority:                        request.Priority,
@@ -352,7 +368,7 @@ func (e *WorkflowExecutor) executeWorkflow(workflow *model.WorkflowDef, request
 		startWorkflowRequest.WorkflowDef = workflow
 	}
 	workflowId, response, err := e.workflowClient.StartWorkflowWithRequest(
-		context.Background(),
+		ctx,
 		startWorkflowRequest,
 	)
 	if err != nil {
@@ -377,6 +393,22 @@ func (e *WorkflowExecutor) executeWorkflow(workflow *model.WorkflowDef, request
 	return workflowId, err
 }
 
+func startWorkflowSpan(ctx context.Context, workflowName string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.StartSpan(ctx, "start_workflow "+workflowName)
+	span.SetAttribute("workflow.name", workflowName)
+	return ctx, span
+}
+
+// injectTraceContext Adds the trace context to the workflow input, keeping the input unchanged when it is not a JSON object
+func injectTraceContext(ctx context.Context, input interface{}) interface{} {
+	tracedInput, err := tracing.InjectIntoInput(ctx, input)
+	if err != nil {
+		log.Debug("Failed to add trace context to workflow input, reason: ", err.Error())
+		return input
+	}
+	return tracedInput
+}
+
 func (e *WorkflowExecutor) startWorkflowDaemon(monitorExecution bool, request *model.StartWorkflowRequest, runningWorkflowChannel chan *RunningWorkflow, waitGroup *sync.WaitGroup) {
 	defer concurrency.HandlePanicError("start_workflow")
 	workflowId, err := e.executeWorkflow(nil, request)
@@ -0,0 +1,89 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+type adminWorkerState struct {
+	TaskName       string              `json:"taskName"`
+	BatchSize      int                 `json:"batchSize"`
+	PollIntervalMs int64               `json:"pollIntervalMs"`
+	Paused         bool                `json:"paused"`
+	LastPollError  *worker.WorkerError `json:"lastPollError"`
+}
+
+func adminRequest(t *testing.T, mux *http.ServeMux, method string, path string, body string, response interface{}) int {
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if response != nil {
+		err := json.NewDecoder(recorder.Body).Decode(response)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return recorder.Code
+}
+
+func TestTaskRunnerAdminHandler(t *testing.T) {
+	taskRunner := worker.NewTaskRunner(
+		nil,
+		settings.NewHttpSettings("http://localhost:1/api"),
+	)
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.StartWorker("unit_test_admin_task", noopWorker, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	taskRunner.MountAdminHandler(mux, "/admin")
+	var state adminWorkerState
+	deadline := time.Now().Add(5 * time.Second)
+	for state.LastPollError == nil && time.Now().Before(deadline) {
+		adminRequest(t, mux, http.MethodGet, "/admin/workers/unit_test_admin_task", "", &state)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if state.LastPollError == nil || state.BatchSize != 1 || state.PollIntervalMs != 1000 {
+		t.Fatal("Unexpected worker state: ", state)
+	}
+	code := adminRequest(t, mux, http.MethodPost, "/admin/workers/unit_test_admin_task/batchSize", `{"batchSize": 3}`, &state)
+	if code != http.StatusOK || state.BatchSize != 3 {
+		t.Fatal("Unexpected batch size update: ", code, state)
+	}
+	code = adminRequest(t, mux, http.MethodPost, "/admin/workers/unit_test_admin_task/pollInterval", `{"pollIntervalMs": 250}`, &state)
+	if code != http.StatusOK || state.PollIntervalMs != 250 {
+		t.Fatal("Unexpected poll interval update: ", code, state)
+	}
+	code = adminRequest(t, mux, http.MethodPost, "/admin/workers/unit_test_admin_task/pause", "", &state)
+	if code != http.StatusOK || !state.Paused {
+		t.Fatal("Unexpected pause: ", code, state)
+	}
+	var states []adminWorkerState
+	code = adminRequest(t, mux, http.MethodGet, "/admin/workers", "", &states)
+	if code != http.StatusOK || len(states) != 1 || !states[0].Paused {
+		t.Fatal("Unexpected workers: ", code, states)
+	}
+	if code := adminRequest(t, mux, http.MethodPost, "/admin/workers/unit_test_admin_task/batchSize", `{"batchSize": 0}`, nil); code != http.StatusBadRequest {
+		t.Fatal("Unexpected status for invalid batch size: ", code)
+	}
+	if code := adminRequest(t, mux, http.MethodGet, "/admin/workers/unit_test_unknown_task", "", nil); code != http.StatusNotFound {
+		t.Fatal("Unexpected status for unknown worker: ", code)
+	}
+}
@@ -0,0 +1,156 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
+)
+
+// newErrorServer Responds to every request for /api/workflow/{status} with the given status and body
+func newErrorServer() *httptest.Server {
+	bodies := map[string]string{
+		"/api/workflow/400": `{"status":400,"message":"Validation failed","validationErrors":[{"path":"name","message":"must not be empty"}]}`,
+		"/api/workflow/401": `{"status":401,"message":"Invalid token"}`,
+		"/api/workflow/404": `{"status":404,"message":"No such workflow found by id: 404","retryable":false}`,
+		"/api/workflow/409": `{"status":409,"message":"Workflow already exists"}`,
+		"/api/workflow/500": `{"status":500,"message":"Database unavailable","retryable":true}`,
+		"/api/workflow/502": `Bad gateway`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status, _ := strconv.Atoi(path.Base(r.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func newErrorServerClient(baseUrl string) *client.APIClient {
+	httpSettings := settings.NewHttpSettings(baseUrl)
+	httpSettings.RetryPolicy = settings.NewNoRetryPolicy()
+	return client.NewAPIClient(nil, httpSettings)
+}
+
+func getExecutionStatus(apiClient *client.APIClient, workflowId string) error {
+	workflowClient := &client.WorkflowResourceApiService{APIClient: apiClient}
+	_, _, err := workflowClient.GetExecutionStatus(context.Background(), workflowId, nil)
+	return err
+}
+
+func TestAPIErrorTypes(t *testing.T) {
+	server := newErrorServer()
+	defer server.Close()
+	apiClient := newErrorServerClient(server.URL + "/api")
+
+	err := getExecutionStatus(apiClient, "404")
+	var notFoundError *client.NotFoundError
+	if !errors.Is(err, client.ErrNotFound) || !errors.As(err, &notFoundError) {
+		t.Fatal("Expected NotFoundError, got: ", err)
+	}
+	if notFoundError.Message != "No such workflow found by id: 404" || notFoundError.StatusCode != http.StatusNotFound {
+		t.Fatal("Expected message from the error body, got: ", notFoundError.Message)
+	}
+	var conflictError *client.ConflictError
+	if err := getExecutionStatus(apiClient, "409"); !errors.Is(err, client.ErrConflict) || !errors.As(err, &conflictError) {
+		t.Fatal("Expected ConflictError, got: ", err)
+	}
+	var unauthorizedError *client.UnauthorizedError
+	if err := getExecutionStatus(apiClient, "401"); !errors.Is(err, client.ErrUnauthorized) || !errors.As(err, &unauthorizedError) {
+		t.Fatal("Expected UnauthorizedError, got: ", err)
+	}
+	err = getExecutionStatus(apiClient, "500")
+	var serverError *client.ServerError
+	if !errors.Is(err, client.ErrServer) || !errors.As(err, &serverError) || !serverError.Retryable {
+		t.Fatal("Expected retryable ServerError, got: ", err)
+	}
+	err = getExecutionStatus(apiClient, "502")
+	if !errors.As(err, &serverError) || serverError.Message != "Bad gateway" || serverError.Retryable {
+		t.Fatal("Expected ServerError with the raw body, got: ", err)
+	}
+}
+
+func TestAPIErrorValidationErrors(t *testing.T) {
+	server := newErrorServer()
+	defer server.Close()
+	err := getExecutionStatus(newErrorServerClient(server.URL+"/api"), "400")
+	var apiError *client.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusBadRequest {
+		t.Fatal("Expected APIError, got: ", err)
+	}
+	expected := []model.ValidationError{{Path: "name", Message: "must not be empty"}}
+	if len(apiError.ValidationErrors) != 1 || apiError.ValidationErrors[0] != expected[0] {
+		t.Fatal("Expected validation errors from the error body, got: ", apiError.ValidationErrors)
+	}
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrServer) {
+		t.Fatal("Expected bad request not to match the other errors")
+	}
+	if !strings.Contains(err.Error(), "name: must not be empty") {
+		t.Fatal("Expected validation errors in the message, got: ", err.Error())
+	}
+}
+
+func TestAPIErrorGenericSwaggerError(t *testing.T) {
+	server := newErrorServer()
+	defer server.Close()
+	err := getExecutionStatus(newErrorServerClient(server.URL+"/api"), "404")
+	var swaggerError client.GenericSwaggerError
+	if !errors.As(err, &swaggerError) {
+		t.Fatal("Expected GenericSwaggerError, got: ", err)
+	}
+	if swaggerError.Error() != "404 Not Found" || !strings.Contains(string(swaggerError.Body()), "No such workflow found") {
+		t.Fatal("Unexpected GenericSwaggerError: ", swaggerError.Error(), ", body: ", string(swaggerError.Body()))
+	}
+}
+
+func TestAPIErrorTransport(t *testing.T) {
+	server := newErrorServer()
+	baseUrl := server.URL + "/api"
+	server.Close()
+	err := getExecutionStatus(newErrorServerClient(baseUrl), "404")
+	var transportError *client.TransportError
+	if !errors.Is(err, client.ErrTransport) || !errors.As(err, &transportError) {
+		t.Fatal("Expected TransportError, got: ", err)
+	}
+}
+
+func TestWorkflowExecutorNotFound(t *testing.T) {
+	server := newErrorServer()
+	defer server.Close()
+	workflowExecutor := executor.NewWorkflowExecutor(newErrorServerClient(server.URL + "/api"))
+	workflow, err := workflowExecutor.GetWorkflow("404", false)
+	if workflow != nil || err != nil {
+		t.Fatal("Expected no workflow and no error, got: ", workflow, ", error: ", err)
+	}
+	workflow, err = workflowExecutor.GetWorkflow("500", false)
+	if workflow != nil || !errors.Is(err, client.ErrServer) {
+		t.Fatal("Expected ServerError, got: ", err)
+	}
+	server.Close()
+	state, err := workflowExecutor.GetWorkflowStatus("404", false, false)
+	if state != nil || !errors.Is(err, client.ErrTransport) {
+		t.Fatal("Expected TransportError, got: ", err)
+	}
+}
@@ -0,0 +1,291 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// authenticationServer Issues tokens with the given lifetime, accepting only the latest one
+type authenticationServer struct {
+	server *httptest.Server
+
+	mutex         sync.Mutex
+	tokenLifetime time.Duration
+	tokenStatus   int
+	issuedTokens  int
+	validToken    string
+}
+
+func newAuthenticationServer(tokenLifetime time.Duration) *authenticationServer {
+	s := &authenticationServer{
+		tokenLifetime: tokenLifetime,
+		tokenStatus:   http.StatusOK,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/token", s.token)
+	mux.HandleFunc("/oauth/token", s.oauth2Token)
+	mux.HandleFunc("/api/health", s.health)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *authenticationServer) token(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.tokenStatus != http.StatusOK {
+		w.WriteHeader(s.tokenStatus)
+		return
+	}
+	s.issuedTokens += 1
+	s.validToken = newJwt(s.issuedTokens, time.Now().Add(s.tokenLifetime))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model.Token{Token: s.validToken})
+}
+
+func (s *authenticationServer) oauth2Token(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok || clientId != "client" || clientSecret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.issuedTokens += 1
+	s.validToken = fmt.Sprintf("access_token_%d", s.issuedTokens)
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"access_token": "%s", "token_type": "Bearer", "expires_in": %d}`, s.validToken, int(s.tokenLifetime.Seconds()))
+}
+
+func (s *authenticationServer) health(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	valid := r.Header.Get("X-Authorization") == s.validToken || r.Header.Get("Authorization") == "Bearer "+s.validToken
+	s.mutex.Unlock()
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model.HealthCheckStatus{Healthy: true})
+}
+
+// revokeToken Makes the server reject the token issued last
+func (s *authenticationServer) revokeToken() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.validToken = "revoked"
+}
+
+func (s *authenticationServer) getIssuedTokens() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.issuedTokens
+}
+
+func (s *authenticationServer) setTokenStatus(status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tokenStatus = status
+}
+
+func (s *authenticationServer) healthCheckClient() *client.HealthCheckResourceApiService {
+	return &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(
+			settings.NewAuthenticationSettings("key", "secret"),
+			settings.NewHttpSettings(s.server.URL+"/api"),
+		),
+	}
+}
+
+func newJwt(id int, expiry time.Time) string {
+	encode := func(value string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(value))
+	}
+	return encode(`{"alg":"none"}`) + "." + encode(fmt.Sprintf(`{"jti":"%d","exp":%d}`, id, expiry.Unix())) + ".signature"
+}
+
+func TestAPIClientRetriesWithFreshToken(t *testing.T) {
+	server := newAuthenticationServer(time.Hour)
+	defer server.server.Close()
+	healthCheckClient := server.healthCheckClient()
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.revokeToken()
+	status, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil || !status.Healthy {
+		t.Fatal("Expected request retried with a fresh token, got: ", err)
+	}
+	if issuedTokens := server.getIssuedTokens(); issuedTokens != 2 {
+		t.Fatal("Expected 2 tokens issued, got: ", issuedTokens)
+	}
+}
+
+func TestAPIClientRefreshesExpiringToken(t *testing.T) {
+	server := newAuthenticationServer(30 * time.Second)
+	defer server.server.Close()
+	healthCheckClient := server.healthCheckClient()
+	for i := 0; i < 2; i++ {
+		_, _, err := healthCheckClient.DoCheck(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if issuedTokens := server.getIssuedTokens(); issuedTokens != 2 {
+		t.Fatal("Expected the token about to expire to be refreshed, tokens issued: ", issuedTokens)
+	}
+}
+
+func TestAPIClientAuthenticationError(t *testing.T) {
+	server := newAuthenticationServer(time.Hour)
+	defer server.server.Close()
+	server.setTokenStatus(http.StatusUnauthorized)
+	_, _, err := server.healthCheckClient().DoCheck(context.Background())
+	var authenticationError *client.AuthenticationError
+	if !errors.As(err, &authenticationError) || authenticationError.StatusCode != http.StatusUnauthorized {
+		t.Fatal("Expected authentication error, got: ", err)
+	}
+}
+
+func TestAPIClientOAuth2ClientCredentials(t *testing.T) {
+	server := newAuthenticationServer(time.Hour)
+	defer server.server.Close()
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClientWithAuthProvider(
+			client.NewOAuth2ClientCredentialsAuthProvider(server.server.URL+"/oauth/token", "client", "secret", "conductor"),
+			settings.NewHttpSettings(server.server.URL+"/api"),
+		),
+	}
+	for i := 0; i < 2; i++ {
+		_, _, err := healthCheckClient.DoCheck(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.revokeToken()
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issuedTokens := server.getIssuedTokens(); issuedTokens != 2 {
+		t.Fatal("Expected a new access token only after the first one was rejected, tokens issued: ", issuedTokens)
+	}
+}
+
+// recordingTransport Records the paths of the requests sent through it
+type recordingTransport struct {
+	mutex sync.Mutex
+	paths []string
+}
+
+func (r *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	r.mutex.Lock()
+	r.paths = append(r.paths, request.URL.Path)
+	r.mutex.Unlock()
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func (r *recordingTransport) hasPath(path string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, p := range r.paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAPIClientOAuth2HttpClient(t *testing.T) {
+	server := newAuthenticationServer(time.Hour)
+	defer server.server.Close()
+	apiTransport := &recordingTransport{}
+	httpSettings := settings.NewHttpSettings(server.server.URL + "/api")
+	httpSettings.Transport = apiTransport
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClientWithAuthProvider(
+			client.NewOAuth2ClientCredentialsAuthProvider(server.server.URL+"/oauth/token", "client", "secret"),
+			httpSettings,
+		),
+	}
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !apiTransport.hasPath("/oauth/token") {
+		t.Fatal("Expected the token request sent with the http settings of the APIClient")
+	}
+
+	tokenTransport := &recordingTransport{}
+	authProvider := client.NewOAuth2ClientCredentialsAuthProvider(server.server.URL+"/oauth/token", "client", "secret")
+	authProvider.SetHttpClient(&http.Client{Transport: tokenTransport})
+	apiTransport = &recordingTransport{}
+	httpSettings.Transport = apiTransport
+	healthCheckClient = &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClientWithAuthProvider(authProvider, httpSettings),
+	}
+	_, _, err = healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tokenTransport.hasPath("/oauth/token") || apiTransport.hasPath("/oauth/token") {
+		t.Fatal("Expected the token request sent with the http client set on the provider")
+	}
+}
+
+func TestAPIClientStaticAuthProviders(t *testing.T) {
+	server := newAuthenticationServer(time.Hour)
+	defer server.server.Close()
+	server.validToken = "static_token"
+	authProviders := []client.AuthProvider{
+		client.NewBearerTokenAuthProvider("static_token"),
+		client.NewHeaderSignerAuthProvider(func(request *http.Request) error {
+			request.Header.Set("X-Authorization", "static_token")
+			return nil
+		}),
+	}
+	for _, authProvider := range authProviders {
+		healthCheckClient := &client.HealthCheckResourceApiService{
+			APIClient: client.NewAPIClientWithAuthProvider(authProvider, settings.NewHttpSettings(server.server.URL+"/api")),
+		}
+		_, _, err := healthCheckClient.DoCheck(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	signingError := errors.New("no signing key")
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClientWithAuthProvider(
+			client.NewHeaderSignerAuthProvider(func(request *http.Request) error {
+				return signingError
+			}),
+			settings.NewHttpSettings(server.server.URL+"/api"),
+		),
+	}
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	var authenticationError *client.AuthenticationError
+	if !errors.As(err, &authenticationError) || !errors.Is(err, signingError) {
+		t.Fatal("Expected authentication error wrapping the signing error, got: ", err)
+	}
+}
@@ -0,0 +1,139 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestQueueDrainPolicy(t *testing.T) {
+	policy := worker.NewQueueDrainPolicy(time.Second)
+	sample := worker.AutoscalingSample{QueueSize: 8, RunningTasks: 2}
+	if batchSize := policy.BatchSize(sample); batchSize != 10 {
+		t.Fatal("Unexpected batch size without execute time: ", batchSize)
+	}
+	sample.AverageExecuteTime = 250 * time.Millisecond
+	if batchSize := policy.BatchSize(sample); batchSize != 3 {
+		t.Fatal("Unexpected batch size: ", batchSize)
+	}
+}
+
+func TestAutoscaler(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.StartWorker("unit_test_autoscaled_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keeps the tasks queued while sampling
+	taskRunner.PauseWorker("unit_test_autoscaled_task")
+	for i := 0; i < 5; i++ {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_autoscaled_task",
+			TaskId:             fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId: "workflow_id",
+		})
+	}
+	autoscaler := worker.NewAutoscaler(taskRunner, worker.NewQueueDrainPolicy(time.Second))
+	if err := autoscaler.SetBounds("unit_test_autoscaled_task", 0, 3); err == nil {
+		t.Fatal("Expected error for invalid bounds")
+	}
+	err = autoscaler.SetBounds("unit_test_autoscaled_task", 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = autoscaler.Scale(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batchSize := taskRunner.GetBatchSizeForTask("unit_test_autoscaled_task"); batchSize != 3 {
+		t.Fatal("Expected batch size clamped to the max bound, got: ", batchSize)
+	}
+	var sample worker.AutoscalingSample
+	autoscaler = worker.NewAutoscaler(taskRunner, worker.AutoscalingPolicyFunc(func(s worker.AutoscalingSample) int {
+		sample = s
+		return 0
+	}))
+	autoscaler.SetBounds("unit_test_autoscaled_task", 2, 3)
+	err = autoscaler.Scale(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.QueueSize != 5 || sample.BatchSize != 3 {
+		t.Fatal("Unexpected autoscaling sample: ", sample)
+	}
+	if batchSize := taskRunner.GetBatchSizeForTask("unit_test_autoscaled_task"); batchSize != 2 {
+		t.Fatal("Expected batch size clamped to the min bound, got: ", batchSize)
+	}
+}
+
+func TestAutoscalerDomainWorker(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	taskName := "unit_test_autoscaled_domain_task"
+	workerName := worker.WorkerName(taskName, "domain_a", "domain_b")
+	err := taskRunner.StartWorkerWithDomains(taskName, func(t *model.Task) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}, 1, 10*time.Millisecond, []string{"domain_a", "domain_b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{TaskDefName: taskName, TaskId: "executed_task_id", WorkflowInstanceId: "workflow_id", Domain: "domain_a"})
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Expected the task to be executed")
+	}
+	// Keeps the tasks queued while sampling
+	taskRunner.PauseWorker(workerName)
+	for i := 0; i < 5; i++ {
+		domain := "domain_a"
+		if i%2 == 1 {
+			domain = "domain_b"
+		}
+		server.addTask(model.Task{
+			TaskDefName:        taskName,
+			TaskId:             fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId: "workflow_id",
+			Domain:             domain,
+		})
+	}
+	var sample worker.AutoscalingSample
+	autoscaler := worker.NewAutoscaler(taskRunner, worker.AutoscalingPolicyFunc(func(s worker.AutoscalingSample) int {
+		sample = s
+		return s.QueueSize
+	}))
+	err = autoscaler.SetBounds(workerName, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = autoscaler.Scale(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.TaskName != taskName || sample.QueueSize != 5 || len(sample.Domains) != 2 {
+		t.Fatal("Unexpected autoscaling sample: ", sample)
+	}
+	if sample.AverageExecuteTime < 20*time.Millisecond {
+		t.Fatal("Expected the average execute time of the worker, got: ", sample.AverageExecuteTime)
+	}
+	if batchSize := taskRunner.GetBatchSizeForTask(workerName); batchSize != 5 {
+		t.Fatal("Expected batch size scaled to the queue size of both domains, got: ", batchSize)
+	}
+}
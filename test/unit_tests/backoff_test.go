@@ -0,0 +1,103 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := worker.NewExponentialBackoff(time.Second)
+	backoff.Jitter = 0
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+	}
+	for i, delay := range expected {
+		if actual := backoff.NextDelay(100*time.Millisecond, i+1); actual != delay {
+			t.Fatal("Unexpected delay for attempt ", i+1, ": ", actual)
+		}
+	}
+	if actual := backoff.NextDelay(5*time.Second, 3); actual != 5*time.Second {
+		t.Fatal("Expected the delay capped at the base delay above the max delay, got: ", actual)
+	}
+	backoff.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := backoff.NextDelay(100*time.Millisecond, 1)
+		if delay < 50*time.Millisecond || delay > 150*time.Millisecond {
+			t.Fatal("Delay out of jitter range: ", delay)
+		}
+	}
+}
+
+func TestTaskRunnerPollTimeout(t *testing.T) {
+	taskRunner := worker.NewTaskRunner(nil, nil)
+	if taskRunner.GetPollTimeout() != 100*time.Millisecond {
+		t.Fatal("Unexpected default poll timeout: ", taskRunner.GetPollTimeout())
+	}
+	if err := taskRunner.SetPollTimeout(time.Minute); err == nil {
+		t.Fatal("Expected error for poll timeout out of range")
+	}
+	if err := taskRunner.SetPollTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskRunner.SetErrorBackoffStrategy(nil); err == nil {
+		t.Fatal("Expected error for nil backoff strategy")
+	}
+}
+
+func TestTaskRunnerWaitsForCapacity(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	for _, taskId := range []string{"first_task_id", "second_task_id"} {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_capacity_task",
+			TaskId:             taskId,
+			WorkflowInstanceId: "workflow_id",
+		})
+	}
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	defer releaseOnce.Do(func() { close(release) })
+	blockingWorker := func(task *model.Task) (interface{}, error) {
+		if task.TaskId == "first_task_id" {
+			<-release
+		}
+		return nil, nil
+	}
+	err := taskRunner.StartWorker("unit_test_capacity_task", blockingWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taskResult, ok := server.waitTaskResult(200 * time.Millisecond); ok {
+		t.Fatal("Task polled beyond the batch size: ", taskResult.TaskId)
+	}
+	releaseOnce.Do(func() { close(release) })
+	for _, taskId := range []string{"first_task_id", "second_task_id"} {
+		taskResult, ok := server.waitTaskResult(5 * time.Second)
+		if !ok {
+			t.Fatal("Task was not updated: ", taskId)
+		}
+		if taskResult.TaskId != taskId {
+			t.Fatal("Unexpected task result: ", taskResult.TaskId)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestTaskRunnerCircuitBreaker(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	server.setUnavailable(true)
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetCircuitBreaker(2, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.SetErrorBackoffStrategy(&worker.ConstantBackoff{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.StartWorker("unit_test_circuit_breaker_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for taskRunner.GetCircuitBreakerState() != worker.CircuitOpen && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if state := taskRunner.GetCircuitBreakerState(); state != worker.CircuitOpen {
+		t.Fatal("Expected open circuit, got: ", state)
+	}
+	polls := server.getPolls()
+	time.Sleep(300 * time.Millisecond)
+	if server.getPolls() != polls {
+		t.Fatal("Polled while the circuit was open")
+	}
+	server.setUnavailable(false)
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_circuit_breaker_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Timed out waiting for task result after the server recovered")
+	}
+	if state := taskRunner.GetCircuitBreakerState(); state != worker.CircuitClosed {
+		t.Fatal("Expected closed circuit, got: ", state)
+	}
+}
+
+func TestTaskRunnerCircuitBreakerSingleProbe(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	server.setUnavailable(true)
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetCircuitBreaker(1, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.SetErrorBackoffStrategy(&worker.ConstantBackoff{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		err = taskRunner.StartWorker(fmt.Sprintf("unit_test_circuit_breaker_probe_task_%d", i), noopWorker, 1, 10*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for taskRunner.GetCircuitBreakerState() != worker.CircuitOpen && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if state := taskRunner.GetCircuitBreakerState(); state != worker.CircuitOpen {
+		t.Fatal("Expected open circuit, got: ", state)
+	}
+	time.Sleep(100 * time.Millisecond)
+	releasePolls := server.holdPolls()
+	polls := server.getPolls()
+	server.setUnavailable(false)
+	deadline = time.Now().Add(5 * time.Second)
+	for server.getPolls() == polls && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+	probes := server.getPolls() - polls
+	state := taskRunner.GetCircuitBreakerState()
+	releasePolls()
+	if probes != 1 {
+		t.Fatal("Expected a single probe of the half open circuit, got: ", probes)
+	}
+	if state != worker.CircuitHalfOpen {
+		t.Fatal("Expected half open circuit while probing, got: ", state)
+	}
+	deadline = time.Now().Add(5 * time.Second)
+	for server.getPolls()-polls < 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if state := taskRunner.GetCircuitBreakerState(); state != worker.CircuitClosed {
+		t.Fatal("Expected closed circuit after the probe, got: ", state)
+	}
+	if server.getPolls()-polls < 5 {
+		t.Fatal("Expected every worker to poll after the probe, got polls: ", server.getPolls()-polls)
+	}
+}
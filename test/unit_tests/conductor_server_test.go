@@ -0,0 +1,252 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+// conductorServer In-memory stand-in for the task endpoints of the Conductor server
+type conductorServer struct {
+	server *httptest.Server
+
+	mutex         sync.Mutex
+	pendingTasks  map[string][]model.Task
+	taskDefs      map[string]model.TaskDef
+	pollWorkerIds map[string]bool
+	polls         int
+	unavailable   bool
+	pollGate      chan struct{}
+	taskResults   chan model.TaskResult
+	taskLogs      chan string
+	workflows     chan model.StartWorkflowRequest
+}
+
+func newConductorServer() *conductorServer {
+	s := &conductorServer{
+		pendingTasks:  make(map[string][]model.Task),
+		taskDefs:      make(map[string]model.TaskDef),
+		pollWorkerIds: make(map[string]bool),
+		taskResults:   make(chan model.TaskResult, 100),
+		taskLogs:      make(chan string, 100),
+		workflows:     make(chan model.StartWorkflowRequest, 100),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tasks/poll/batch/", s.batchPoll)
+	mux.HandleFunc("/api/tasks", s.updateTask)
+	mux.HandleFunc("/api/tasks/externalstoragelocation", s.externalStorageLocation)
+	mux.HandleFunc("/api/tasks/queue/sizes", s.queueSizes)
+	mux.HandleFunc("/api/metadata/taskdefs/", s.taskDef)
+	mux.HandleFunc("/api/tasks/", s.log)
+	mux.HandleFunc("/api/health", s.health)
+	mux.HandleFunc("/api/workflow", s.startWorkflow)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *conductorServer) httpSettings() *settings.HttpSettings {
+	return settings.NewHttpSettings(s.server.URL + "/api")
+}
+
+func (s *conductorServer) close() {
+	s.server.Close()
+}
+
+func (s *conductorServer) addTask(task model.Task) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	queueName := worker.WorkerName(task.TaskDefName, task.Domain)
+	s.pendingTasks[queueName] = append(s.pendingTasks[queueName], task)
+}
+
+func (s *conductorServer) waitTaskResult(timeout time.Duration) (*model.TaskResult, bool) {
+	select {
+	case taskResult := <-s.taskResults:
+		return &taskResult, true
+	case <-time.After(timeout):
+		return nil, false
+	}
+}
+
+func (s *conductorServer) batchPoll(w http.ResponseWriter, r *http.Request) {
+	taskName := worker.WorkerName(
+		strings.TrimPrefix(r.URL.Path, "/api/tasks/poll/batch/"),
+		r.URL.Query().Get("domain"),
+	)
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 1 {
+		count = 1
+	}
+	s.mutex.Lock()
+	s.polls += 1
+	if s.unavailable {
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	s.pollWorkerIds[r.URL.Query().Get("workerid")] = true
+	pollGate := s.pollGate
+	s.mutex.Unlock()
+	if pollGate != nil {
+		<-pollGate
+	}
+	s.mutex.Lock()
+	tasks := s.pendingTasks[taskName]
+	if len(tasks) > count {
+		tasks = tasks[:count]
+	}
+	s.pendingTasks[taskName] = s.pendingTasks[taskName][len(tasks):]
+	s.mutex.Unlock()
+	if len(tasks) < 1 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
+
+func (s *conductorServer) startWorkflow(w http.ResponseWriter, r *http.Request) {
+	var request model.StartWorkflowRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.workflows <- request
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode("workflow_id")
+}
+
+func (s *conductorServer) waitWorkflow(timeout time.Duration) (*model.StartWorkflowRequest, bool) {
+	select {
+	case request := <-s.workflows:
+		return &request, true
+	case <-time.After(timeout):
+		return nil, false
+	}
+}
+
+// holdPolls Keeps the polls open until the returned function is called, answering them with the tasks added meanwhile
+func (s *conductorServer) holdPolls() func() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	pollGate := make(chan struct{})
+	s.pollGate = pollGate
+	var releaseOnce sync.Once
+	return func() {
+		releaseOnce.Do(func() {
+			s.mutex.Lock()
+			s.pollGate = nil
+			s.mutex.Unlock()
+			close(pollGate)
+		})
+	}
+}
+
+// setUnavailable Makes the polls fail and the health check report unhealthy
+func (s *conductorServer) setUnavailable(unavailable bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unavailable = unavailable
+}
+
+func (s *conductorServer) getPolls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.polls
+}
+
+func (s *conductorServer) health(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	healthy := !s.unavailable
+	s.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model.HealthCheckStatus{Healthy: healthy})
+}
+
+func (s *conductorServer) hasPolledWorkerId(workerId string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.pollWorkerIds[workerId]
+}
+
+func (s *conductorServer) addTaskDef(taskDef model.TaskDef) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.taskDefs[taskDef.Name] = taskDef
+}
+
+func (s *conductorServer) taskDef(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	taskDef, ok := s.taskDefs[strings.TrimPrefix(r.URL.Path, "/api/metadata/taskdefs/")]
+	s.mutex.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taskDef)
+}
+
+func (s *conductorServer) queueSizes(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	sizes := make(map[string]int)
+	for _, taskName := range r.URL.Query()["taskType"] {
+		sizes[taskName] = len(s.pendingTasks[taskName])
+	}
+	s.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sizes)
+}
+
+func (s *conductorServer) updateTask(w http.ResponseWriter, r *http.Request) {
+	var taskResult model.TaskResult
+	err := json.NewDecoder(r.Body).Decode(&taskResult)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.taskResults <- taskResult
+	w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+	w.Write([]byte(taskResult.TaskId))
+}
+
+func (s *conductorServer) log(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/log") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.taskLogs <- string(body)
+}
+
+func (s *conductorServer) externalStorageLocation(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model.ExternalStorageLocation{
+		Uri:  "file://" + path,
+		Path: path,
+	})
+}
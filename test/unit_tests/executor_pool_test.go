@@ -0,0 +1,210 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTaskRunnerExecutorPool(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetExecutorPool(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taskRunner.SetExecutorPool(1, 0) == nil {
+		t.Fatal("Expected error when setting the executor pool twice")
+	}
+	for i := 0; i < 3; i++ {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_executor_pool_task",
+			TaskId:             fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId: "workflow_id",
+		})
+	}
+	var running, maxRunning int32
+	executeFunction := func(task *model.Task) (interface{}, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		return nil, nil
+	}
+	err = taskRunner.StartWorker("unit_test_executor_pool_task", executeFunction, 3, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+			t.Fatal("Timed out waiting for task result")
+		}
+	}
+	if maxRunning := atomic.LoadInt32(&maxRunning); maxRunning != 1 {
+		t.Fatal("Expected tasks executed one at a time, got: ", maxRunning)
+	}
+	if queueFull := getCounterValue(t, string(metrics.TASK_EXECUTION_QUEUE_FULL), "unit_test_executor_pool_task"); queueFull < 1 {
+		t.Fatal("Expected the execution queue to be reported full")
+	}
+}
+
+func TestTaskRunnerExecutorPoolExpiredTask(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetExecutorPool(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_executor_pool_expired_task",
+		TaskId:             "slow_task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	server.addTask(model.Task{
+		TaskDefName:            "unit_test_executor_pool_expired_task",
+		TaskId:                 "expired_task_id",
+		WorkflowInstanceId:     "workflow_id",
+		ResponseTimeoutSeconds: 1,
+	})
+	var executedExpired int32
+	executeFunction := func(task *model.Task) (interface{}, error) {
+		if task.TaskId == "expired_task_id" {
+			atomic.StoreInt32(&executedExpired, 1)
+			return nil, nil
+		}
+		time.Sleep(1200 * time.Millisecond)
+		return nil, nil
+	}
+	err = taskRunner.StartWorker("unit_test_executor_pool_expired_task", executeFunction, 2, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Timed out waiting for task result")
+	}
+	if taskResult.TaskId != "slow_task_id" {
+		t.Fatal("Expected the result of the slow task, got: ", taskResult.TaskId)
+	}
+	if _, ok := server.waitTaskResult(500 * time.Millisecond); ok {
+		t.Fatal("Expected the task that waited past its response timeout not to be updated")
+	}
+	if atomic.LoadInt32(&executedExpired) != 0 {
+		t.Fatal("Expected the task that waited past its response timeout not to be executed")
+	}
+	if expired := getCounterValue(t, string(metrics.TASK_EXECUTION_EXPIRED), "unit_test_executor_pool_expired_task"); expired < 1 {
+		t.Fatal("Expected the expired task to be reported")
+	}
+	description, err := taskRunner.DescribeWorker("unit_test_executor_pool_expired_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description.RunningTasks != 0 {
+		t.Fatal("Expected no running tasks, got: ", description.RunningTasks)
+	}
+}
+
+func TestTaskRunnerExecutorPoolFullOnShutdown(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	err := taskRunner.SetExecutorPool(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_executor_pool_shutdown_task",
+			TaskId:             fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId: "workflow_id",
+		})
+	}
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	blockingWorker := func(task *model.Task) (interface{}, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}
+	err = taskRunner.StartWorker("unit_test_executor_pool_shutdown_task", blockingWorker, 3, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	type shutdownResult struct {
+		report *worker.ShutdownReport
+		err    error
+	}
+	shutdownResults := make(chan shutdownResult, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		report, err := taskRunner.Shutdown(ctx)
+		shutdownResults <- shutdownResult{report, err}
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	result := <-shutdownResults
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	if len(result.report.AbandonedTasks) != 2 {
+		t.Fatal("Expected the tasks waiting for the full executor pool to be abandoned, got: ", result.report.AbandonedTasks)
+	}
+	taskResult, ok := server.waitTaskResult(time.Second)
+	if !ok || taskResult.TaskId != "task_id_0" {
+		t.Fatal("Expected the running task to be updated, got: ", taskResult)
+	}
+	if taskResult, ok := server.waitTaskResult(200 * time.Millisecond); ok {
+		t.Fatal("Expected the abandoned tasks not to be updated, got: ", taskResult.TaskId)
+	}
+	if len(started) != 0 {
+		t.Fatal("Expected the abandoned tasks not to be executed")
+	}
+}
+
+func getCounterValue(t *testing.T, name string, taskType string) float64 {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != name {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == string(metrics.TASK_TYPE) && label.GetValue() == taskType {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/storage"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestTaskRunnerExternalPayloadStorage(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	localStorage, err := storage.NewLocalFileSystemStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputPath, err := localStorage.Upload(map[string]interface{}{"size": 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	taskRunner.SetExternalStorageSettings(localStorage.NewExternalStorageSettings(1, 10))
+	server.addTask(model.Task{
+		TaskDefName:                     "unit_test_external_payload_task",
+		TaskId:                          "task_id",
+		WorkflowInstanceId:              "workflow_id",
+		ExternalInputPayloadStoragePath: inputPath,
+	})
+	largeOutputWorker := func(task *model.Task) (interface{}, error) {
+		size := int(task.InputData["size"].(float64))
+		return map[string]interface{}{"data": strings.Repeat("a", size)}, nil
+	}
+	err = taskRunner.StartWorker("unit_test_external_payload_task", largeOutputWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Task was not updated")
+	}
+	if taskResult.Status != model.CompletedTask || taskResult.OutputData != nil || taskResult.ExternalOutputPayloadStoragePath == "" {
+		t.Fatal("Unexpected task result: ", *taskResult)
+	}
+	outputData, err := localStorage.Download(taskResult.ExternalOutputPayloadStoragePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputData["data"].(string)) != 2048 {
+		t.Fatal("Unexpected external output")
+	}
+}
@@ -0,0 +1,166 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func healthy(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(model.HealthCheckStatus{Healthy: true})
+}
+
+func doHealthCheck(httpSettings *settings.HttpSettings) error {
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, httpSettings),
+	}
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	return err
+}
+
+func writePem(t *testing.T, path string, blockType string, bytes []byte) {
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newClientCertificate Writes a self-signed client certificate and its key, returning it
+func newClientCertificate(t *testing.T, certFile string, keyFile string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "unit_test_client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePem(t, certFile, "CERTIFICATE", certBytes)
+	writePem(t, keyFile, "EC PRIVATE KEY", keyBytes)
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestHttpSettingsMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCertFile := filepath.Join(dir, "ca.pem")
+	clientCertFile := filepath.Join(dir, "client.pem")
+	clientKeyFile := filepath.Join(dir, "client-key.pem")
+	clientCert := newClientCertificate(t, clientCertFile, clientKeyFile)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(healthy))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+	writePem(t, caCertFile, "CERTIFICATE", server.Certificate().Raw)
+
+	httpSettings := settings.NewHttpSettings(server.URL + "/api")
+	httpSettings.CACertFile = caCertFile
+	if err := doHealthCheck(httpSettings); err == nil {
+		t.Fatal("Expected the server to require a client certificate")
+	}
+	httpSettings.ClientCertFile = clientCertFile
+	httpSettings.ClientKeyFile = clientKeyFile
+	if err := doHealthCheck(httpSettings); err != nil {
+		t.Fatal(err)
+	}
+	httpSettings.CACertFile = filepath.Join(dir, "missing.pem")
+	if err := doHealthCheck(httpSettings); err == nil || !strings.Contains(err.Error(), "failed to read CA bundle") {
+		t.Fatal("Expected error for missing CA bundle, got: ", err)
+	}
+}
+
+func TestHttpSettingsProxy(t *testing.T) {
+	var proxiedHost atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost.Store(r.URL.Host)
+		healthy(w, r)
+	}))
+	defer proxy.Close()
+	httpSettings := settings.NewHttpSettings("http://conductor.invalid/api")
+	httpSettings.ProxyUrl = proxy.URL
+	if err := doHealthCheck(httpSettings); err != nil {
+		t.Fatal(err)
+	}
+	if host := proxiedHost.Load(); host != "conductor.invalid" {
+		t.Fatal("Expected request through the proxy, got host: ", host)
+	}
+}
+
+func TestHttpSettingsCustomTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(healthy))
+	defer server.Close()
+	var requests int32
+	httpSettings := settings.NewHttpSettings(server.URL + "/api")
+	httpSettings.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		return http.DefaultTransport.RoundTrip(request)
+	})
+	if err := doHealthCheck(httpSettings); err != nil {
+		t.Fatal(err)
+	}
+	httpSettings.HttpClient = &http.Client{Transport: httpSettings.Transport, Timeout: time.Second}
+	httpSettings.Transport = nil
+	if err := doHealthCheck(httpSettings); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&requests) != 2 {
+		t.Fatal("Expected both requests sent through the custom transport, got: ", requests)
+	}
+}
@@ -0,0 +1,74 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestTaskRunnerRateLimit(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetRateLimit("unit_test_rate_limited_task", 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_rate_limited_task",
+			TaskId:             fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId: "workflow_id",
+		})
+	}
+	startTime := time.Now()
+	err = taskRunner.StartWorker("unit_test_rate_limited_task", noopWorker, 5, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+			t.Fatal("Task was not updated")
+		}
+	}
+	if spentTime := time.Since(startTime); spentTime < 350*time.Millisecond {
+		t.Fatal("Executions were not rate limited, spent: ", spentTime)
+	}
+}
+
+func TestTaskRunnerRateLimitFromTaskDef(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	server.addTaskDef(model.TaskDef{
+		Name:                        "unit_test_rate_limited_task",
+		RateLimitPerFrequency:       100,
+		RateLimitFrequencyInSeconds: 10,
+	})
+	err := taskRunner.SetRateLimitFromTaskDef("unit_test_rate_limited_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.SetRateLimitFromTaskDef("unit_test_unknown_task")
+	if err == nil {
+		t.Fatal("Expected error for unknown task definition")
+	}
+	err = taskRunner.SetRateLimit("unit_test_rate_limited_task", 1, 0)
+	if err == nil {
+		t.Fatal("Expected error for rate limit without burst")
+	}
+}
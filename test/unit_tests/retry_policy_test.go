@@ -0,0 +1,192 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// flakyServer Fails the first requests with the given status, then succeeds
+type flakyServer struct {
+	server *httptest.Server
+
+	mutex      sync.Mutex
+	failures   int
+	status     int
+	retryAfter string
+	requests   int
+	bodies     []string
+}
+
+func newFlakyServer(failures int, status int) *flakyServer {
+	s := &flakyServer{
+		failures: failures,
+		status:   status,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *flakyServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.mutex.Lock()
+	s.requests += 1
+	s.bodies = append(s.bodies, string(body))
+	failed := s.requests <= s.failures
+	s.mutex.Unlock()
+	if failed {
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		w.WriteHeader(s.status)
+		return
+	}
+	if r.Method == http.MethodPost {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+		return
+	}
+	healthy(w, r)
+}
+
+func (s *flakyServer) getRequests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+func (s *flakyServer) getBodies() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.bodies...)
+}
+
+func newFastRetryPolicy() *settings.RetryPolicy {
+	policy := settings.NewDefaultRetryPolicy()
+	policy.InitialDelay = time.Millisecond
+	return policy
+}
+
+func newRetryHttpSettings(s *flakyServer, policy *settings.RetryPolicy) *settings.HttpSettings {
+	httpSettings := settings.NewHttpSettings(s.server.URL + "/api")
+	httpSettings.RetryPolicy = policy
+	return httpSettings
+}
+
+func TestRetryPolicyRetriesIdempotentRequests(t *testing.T) {
+	s := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer s.server.Close()
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, newFastRetryPolicy())),
+	}
+	status, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Healthy || s.getRequests() != 3 {
+		t.Fatal("Expected healthy status after 3 requests, got: ", s.getRequests())
+	}
+}
+
+func TestRetryPolicyMaxAttempts(t *testing.T) {
+	s := newFlakyServer(10, http.StatusBadGateway)
+	defer s.server.Close()
+	policy := newFastRetryPolicy()
+	policy.MaxAttempts = 4
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, policy)),
+	}
+	_, response, err := healthCheckClient.DoCheck(context.Background())
+	if err == nil || response == nil || response.StatusCode != http.StatusBadGateway {
+		t.Fatal("Expected the last failed response, got: ", response, ", error: ", err)
+	}
+	if s.getRequests() != 4 {
+		t.Fatal("Expected 4 requests, got: ", s.getRequests())
+	}
+}
+
+func TestRetryPolicyNonRetryableStatus(t *testing.T) {
+	s := newFlakyServer(1, http.StatusBadRequest)
+	defer s.server.Close()
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, newFastRetryPolicy())),
+	}
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err == nil || s.getRequests() != 1 {
+		t.Fatal("Expected a single failed request, got: ", s.getRequests(), ", error: ", err)
+	}
+}
+
+func TestRetryPolicyNonIdempotentRequests(t *testing.T) {
+	s := newFlakyServer(1, http.StatusServiceUnavailable)
+	defer s.server.Close()
+	policy := newFastRetryPolicy()
+	taskClient := &client.TaskResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, policy)),
+	}
+	taskResult := &model.TaskResult{TaskId: "task_id", Status: model.CompletedTask}
+	_, _, err := taskClient.UpdateTask(context.Background(), taskResult)
+	if err == nil || s.getRequests() != 1 {
+		t.Fatal("Expected POST not to be retried, got requests: ", s.getRequests(), ", error: ", err)
+	}
+	policy.RetryNonIdempotent = true
+	_, _, err = taskClient.UpdateTask(context.Background(), taskResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodies := s.getBodies()
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Fatal("Expected the same body sent on every attempt, got: ", bodies)
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	s := newFlakyServer(1, http.StatusTooManyRequests)
+	s.retryAfter = "1"
+	defer s.server.Close()
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, newFastRetryPolicy())),
+	}
+	startTime := time.Now()
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(startTime); elapsed < time.Second {
+		t.Fatal("Expected to wait for Retry-After, waited: ", elapsed)
+	}
+}
+
+func TestRetryPolicyContextCancelled(t *testing.T) {
+	s := newFlakyServer(10, http.StatusServiceUnavailable)
+	defer s.server.Close()
+	policy := newFastRetryPolicy()
+	policy.InitialDelay = time.Minute
+	policy.MaxDelay = time.Minute
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, newRetryHttpSettings(s, policy)),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err := healthCheckClient.DoCheck(ctx)
+	if err != context.DeadlineExceeded || s.getRequests() != 1 {
+		t.Fatal("Expected to stop waiting when the context is done, got requests: ", s.getRequests(), ", error: ", err)
+	}
+}
@@ -0,0 +1,78 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestTaskResultOutboxReplay(t *testing.T) {
+	directory := t.TempDir()
+	outbox, err := worker.NewTaskResultOutbox(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, taskId := range []string{"first_task_id", "second_task_id"} {
+		err := outbox.Add("unit_test_outbox_task", &model.TaskResult{
+			TaskId:             taskId,
+			WorkflowInstanceId: "workflow_id",
+			Status:             model.CompletedTask,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Simulates a process stopped while writing an entry
+	file, err := os.OpenFile(filepath.Join(directory, "task_results.jsonl"), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"taskName":"unit_test_outbox_task","taskRes`)
+	file.Close()
+	outbox, err = worker.NewTaskResultOutbox(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outbox.Size() != 2 {
+		t.Fatal("Unexpected outbox size after reopening: ", outbox.Size())
+	}
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	taskRunner.SetTaskResultOutbox(outbox)
+	for _, taskId := range []string{"first_task_id", "second_task_id"} {
+		taskResult, ok := server.waitTaskResult(5 * time.Second)
+		if !ok {
+			t.Fatal("Task result was not replayed: ", taskId)
+		}
+		if taskResult.TaskId != taskId {
+			t.Fatal("Unexpected task result: ", taskResult.TaskId)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for outbox.Size() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	outbox, err = worker.NewTaskResultOutbox(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outbox.Size() != 0 {
+		t.Fatal("Replayed task results were not removed from the outbox: ", outbox.Size())
+	}
+}
@@ -0,0 +1,244 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/tracing"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	input, err := tracing.InjectIntoInput(context.Background(), map[string]interface{}{"key": "value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := input.(map[string]interface{})[tracing.TraceParentKey]; ok {
+		t.Fatal("Trace context injected without exporter")
+	}
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+	ctx, span := tracing.StartSpan(context.Background(), "unit_test_span")
+	input, err = tracing.InjectIntoInput(ctx, struct {
+		Key string `json:"key"`
+	}{Key: "value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carrier := input.(map[string]interface{})
+	if carrier["key"] != "value" {
+		t.Fatal("Unexpected input: ", carrier)
+	}
+	spanContext := tracing.SpanContextFromContext(tracing.Extract(context.Background(), carrier))
+	if spanContext != span.SpanContext() {
+		t.Fatal("Unexpected extracted span context: ", spanContext)
+	}
+	_, childSpan := tracing.StartSpan(tracing.Extract(context.Background(), carrier), "unit_test_child_span")
+	childSpan.End(nil)
+	span.End(nil)
+	spans := exporter.Spans()
+	if len(spans) != 2 || spans[0].TraceId != span.TraceId || spans[0].ParentSpanId != span.SpanId {
+		t.Fatal("Unexpected spans: ", spans)
+	}
+	if _, ok := tracing.ParseTraceParent("00-invalid-01"); ok {
+		t.Fatal("Expected invalid traceparent")
+	}
+}
+
+func TestTaskRunnerTracing(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	_, workflowSpan := tracing.StartSpan(context.Background(), "unit_test_workflow")
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_traced_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+		InputData: map[string]interface{}{
+			tracing.TraceParentKey: tracing.FormatTraceParent(workflowSpan.SpanContext()),
+		},
+	})
+	var executionSpanContext tracing.SpanContext
+	tracedWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		executionSpanContext = tracing.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+	err := taskRunner.StartContextWorker("unit_test_traced_task", tracedWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Task was not updated")
+	}
+	taskRunner.Shutdown(context.Background())
+	spanByName := make(map[string]*tracing.Span)
+	for _, span := range exporter.Spans() {
+		spanByName[span.Name] = span
+	}
+	executeSpan, ok := spanByName["execute unit_test_traced_task"]
+	if !ok || executeSpan.TraceId != workflowSpan.TraceId || executeSpan.ParentSpanId != workflowSpan.SpanId {
+		t.Fatal("Unexpected execute span: ", executeSpan)
+	}
+	if executionSpanContext != executeSpan.SpanContext() {
+		t.Fatal("Execution context does not carry the execute span")
+	}
+	updateSpan, ok := spanByName["update unit_test_traced_task"]
+	if !ok || updateSpan.TraceId != workflowSpan.TraceId || updateSpan.Error != "" {
+		t.Fatal("Unexpected update span: ", updateSpan)
+	}
+	httpSpan, ok := spanByName["HTTP POST"]
+	if !ok || httpSpan.ParentSpanId != updateSpan.SpanId || httpSpan.Attributes["http.status_code"] != "200" {
+		t.Fatal("Unexpected HTTP span: ", httpSpan)
+	}
+	if _, ok := spanByName["poll unit_test_traced_task"]; !ok {
+		t.Fatal("Missing poll span")
+	}
+}
+
+func TestWorkflowToTaskTracePropagation(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+	server := newConductorServer()
+	defer server.close()
+	apiClient := client.NewAPIClient(nil, server.httpSettings())
+	workflowExecutor := executor.NewWorkflowExecutor(apiClient)
+	_, err := workflowExecutor.StartWorkflow(&model.StartWorkflowRequest{
+		Name:  "unit_test_traced_workflow",
+		Input: map[string]interface{}{"key": "value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, ok := server.waitWorkflow(5 * time.Second)
+	if !ok {
+		t.Fatal("Workflow was not started")
+	}
+	workflowInput := request.Input.(map[string]interface{})
+	// The server only passes traceparent to the tasks mapping it: "traceparent": "${workflow.input.traceparent}"
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_propagated_task",
+		TaskId:             "mapped_task_id",
+		WorkflowInstanceId: "workflow_id",
+		InputData: map[string]interface{}{
+			tracing.TraceParentKey: workflowInput[tracing.TraceParentKey],
+		},
+	})
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_propagated_task",
+		TaskId:             "unmapped_task_id",
+		WorkflowInstanceId: "workflow_id",
+		InputData:          map[string]interface{}{"key": "value"},
+	})
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	err = taskRunner.StartWorker("unit_test_propagated_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+			t.Fatal("Task was not updated")
+		}
+	}
+	taskRunner.Shutdown(context.Background())
+	var workflowSpan *tracing.Span
+	executeSpanByTaskId := make(map[string]*tracing.Span)
+	for _, span := range exporter.Spans() {
+		switch span.Name {
+		case "start_workflow unit_test_traced_workflow":
+			workflowSpan = span
+		case "execute unit_test_propagated_task":
+			executeSpanByTaskId[span.Attributes["task.id"]] = span
+		}
+	}
+	if workflowSpan == nil {
+		t.Fatal("Missing start workflow span")
+	}
+	mappedSpan, ok := executeSpanByTaskId["mapped_task_id"]
+	if !ok || mappedSpan.TraceId != workflowSpan.TraceId || mappedSpan.ParentSpanId != workflowSpan.SpanId {
+		t.Fatal("Expected the execution of the task mapping traceparent in the workflow trace, got: ", mappedSpan)
+	}
+	unmappedSpan, ok := executeSpanByTaskId["unmapped_task_id"]
+	if !ok || unmappedSpan.TraceId == workflowSpan.TraceId {
+		t.Fatal("Expected the execution of the task without traceparent in a new trace, got: ", unmappedSpan)
+	}
+}
+
+func TestTaskRunnerNoSpansWithoutExporter(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_untraced_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	var executionSpanContext tracing.SpanContext
+	untracedWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		executionSpanContext = tracing.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+	err := taskRunner.StartContextWorker("unit_test_untraced_task", untracedWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Task was not updated")
+	}
+	taskRunner.Shutdown(context.Background())
+	if executionSpanContext.IsValid() {
+		t.Fatal("Expected no execute span without exporter, got: ", executionSpanContext)
+	}
+}
+
+func TestAPIClientTraceParentHeader(t *testing.T) {
+	traceParents := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents <- r.Header.Get(tracing.TraceParentKey)
+		healthy(w, r)
+	}))
+	defer server.Close()
+	healthCheckClient := &client.HealthCheckResourceApiService{
+		APIClient: client.NewAPIClient(nil, settings.NewHttpSettings(server.URL+"/api")),
+	}
+	_, _, err := healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traceParent := <-traceParents; traceParent != "" {
+		t.Fatal("Expected no traceparent header without exporter, got: ", traceParent)
+	}
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+	_, _, err = healthCheckClient.DoCheck(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tracing.ParseTraceParent(<-traceParents); !ok {
+		t.Fatal("Expected traceparent header once tracing is enabled")
+	}
+	if spans := exporter.Spans(); len(spans) != 1 || spans[0].Name != "HTTP GET" {
+		t.Fatal("Unexpected spans: ", spans)
+	}
+}
@@ -0,0 +1,185 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+type greetingInput struct {
+	Name  string `json:"name"`
+	Times int    `json:"times"`
+}
+
+type greetingOutput struct {
+	Greeting string `json:"greeting"`
+}
+
+func greet(input greetingInput) (*greetingOutput, error) {
+	return &greetingOutput{
+		Greeting: strings.Repeat("hello "+input.Name+" ", input.Times),
+	}, nil
+}
+
+func TestTypedExecuteFunction(t *testing.T) {
+	executeFunction, err := worker.NewTypedExecuteFunction(greet, worker.StrictDecoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := executeFunction(&model.Task{
+		InputData: map[string]interface{}{
+			"name":  "conductor",
+			"times": float64(1),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.(*greetingOutput).Greeting != "hello conductor " {
+		t.Fatal("Unexpected output: ", output)
+	}
+}
+
+func TestTypedExecuteFunctionDecodingFailure(t *testing.T) {
+	executeFunction, err := worker.NewTypedExecuteFunction(greet, worker.StrictDecoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := executeFunction(&model.Task{
+		InputData: map[string]interface{}{
+			"times":   "2",
+			"unknown": true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult := output.(*model.TaskResult)
+	if taskResult.Status != model.FailedWithTerminalErrorTask {
+		t.Fatal("Unexpected task status: ", taskResult.Status)
+	}
+	if !strings.Contains(taskResult.ReasonForIncompletion, "times: expected int") ||
+		!strings.Contains(taskResult.ReasonForIncompletion, "unknown: unknown field") {
+		t.Fatal("Unexpected reason for incompletion: ", taskResult.ReasonForIncompletion)
+	}
+}
+
+func TestTypedExecuteFunctionLenientDecoding(t *testing.T) {
+	input := greetingInput{}
+	err := worker.DecodeTaskInput(
+		&model.Task{
+			InputData: map[string]interface{}{
+				"name":    float64(7),
+				"times":   "2",
+				"unknown": true,
+			},
+		},
+		&input,
+		worker.LenientDecoding,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.Name != "7" || input.Times != 2 {
+		t.Fatal("Unexpected input: ", input)
+	}
+}
+
+func TestTypedExecuteFunctionInvalidSignature(t *testing.T) {
+	_, err := worker.NewTypedExecuteFunction(func(name string) (string, error) { return name, nil }, worker.StrictDecoding)
+	if err == nil {
+		t.Fatal("Expected error for input that is not a struct")
+	}
+}
+
+type AuditInfo struct {
+	RequestedBy string `json:"requestedBy"`
+}
+
+type orderLabel string
+
+type orderInput struct {
+	*AuditInfo
+	orderLabel
+	OrderId string
+	Amount  int `json:"amount"`
+}
+
+func TestTypedExecuteFunctionCaseInsensitiveFields(t *testing.T) {
+	input := orderInput{}
+	err := worker.DecodeTaskInput(
+		&model.Task{
+			InputData: map[string]interface{}{
+				"orderId": "order_id",
+				"AMOUNT":  float64(3),
+			},
+		},
+		&input,
+		worker.StrictDecoding,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.OrderId != "order_id" || input.Amount != 3 {
+		t.Fatal("Unexpected input: ", input)
+	}
+}
+
+func TestTypedExecuteFunctionEmbeddedStructPointer(t *testing.T) {
+	input := orderInput{}
+	err := worker.DecodeTaskInput(
+		&model.Task{
+			InputData: map[string]interface{}{
+				"OrderId":     "order_id",
+				"requestedBy": "user",
+			},
+		},
+		&input,
+		worker.StrictDecoding,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.AuditInfo == nil || input.RequestedBy != "user" {
+		t.Fatal("Expected promoted fields of the embedded pointer to be decoded, got: ", input)
+	}
+	input = orderInput{}
+	err = worker.DecodeTaskInput(&model.Task{InputData: map[string]interface{}{"OrderId": "order_id"}}, &input, worker.StrictDecoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.AuditInfo != nil {
+		t.Fatal("Expected embedded pointer left nil without its fields, got: ", input.AuditInfo)
+	}
+}
+
+func TestTypedExecuteFunctionUnexportedEmbeddedField(t *testing.T) {
+	input := orderInput{}
+	err := worker.DecodeTaskInput(
+		&model.Task{
+			InputData: map[string]interface{}{
+				"orderLabel": "label",
+				"OrderId":    "order_id",
+			},
+		},
+		&input,
+		worker.LenientDecoding,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.orderLabel != "" || input.OrderId != "order_id" {
+		t.Fatal("Expected unexported embedded field to be skipped, got: ", input)
+	}
+}
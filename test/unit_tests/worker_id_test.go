@@ -0,0 +1,119 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+)
+
+func TestWorkerIdStrategies(t *testing.T) {
+	hostname, _ := os.Hostname()
+	if workerId := worker.HostnamePidWorkerId().WorkerId("task"); workerId != fmt.Sprintf("%s-%d", hostname, os.Getpid()) {
+		t.Fatal("Unexpected hostname and pid worker id: ", workerId)
+	}
+	t.Setenv(worker.PodNameEnv, "pod-1")
+	if workerId := worker.EnvWorkerId(worker.PodNameEnv).WorkerId("task"); workerId != "pod-1" {
+		t.Fatal("Unexpected pod worker id: ", workerId)
+	}
+	t.Setenv(worker.PodNameEnv, "")
+	if workerId := worker.EnvWorkerId(worker.PodNameEnv).WorkerId("task"); workerId != hostname {
+		t.Fatal("Expected hostname when the environment variable is not set, got: ", workerId)
+	}
+}
+
+func TestTaskResultWorkerId(t *testing.T) {
+	if taskResult := model.NewTaskResultFromTask(&model.Task{WorkerId: "pod-1"}); taskResult.WorkerId != "pod-1" {
+		t.Fatal("Expected the worker id of the task, got: ", taskResult.WorkerId)
+	}
+	if taskResult := model.NewTaskResultFromTask(&model.Task{}); taskResult.WorkerId != model.GetHostname() {
+		t.Fatal("Expected the hostname, got: ", taskResult.WorkerId)
+	}
+}
+
+func TestTaskRunnerWorkerIdInReturnedResult(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetWorkerIdStrategy(worker.StaticWorkerId("pod-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_returned_result_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	executeFunction := func(task *model.Task) (interface{}, error) {
+		taskResult := model.NewTaskResultFromTask(task)
+		taskResult.Status = model.CompletedTask
+		return taskResult, nil
+	}
+	err = taskRunner.StartWorker("unit_test_returned_result_task", executeFunction, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Timed out waiting for task result")
+	}
+	if taskResult.WorkerId != "pod-1" {
+		t.Fatal("Unexpected worker id in the returned task result: ", taskResult.WorkerId)
+	}
+}
+
+func TestTaskRunnerWorkerIdStrategy(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetWorkerIdStrategy(worker.WorkerIdStrategyFunc(func(taskName string) string {
+		return "custom-" + taskName
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_worker_id_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	err = taskRunner.StartWorker("unit_test_worker_id_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Timed out waiting for task result")
+	}
+	if taskResult.WorkerId != "custom-unit_test_worker_id_task" {
+		t.Fatal("Unexpected worker id in task result: ", taskResult.WorkerId)
+	}
+	if !server.hasPolledWorkerId("custom-unit_test_worker_id_task") {
+		t.Fatal("Expected the poll to report the worker id")
+	}
+	description, err := taskRunner.DescribeWorker("unit_test_worker_id_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description.Identity != "custom-unit_test_worker_id_task" {
+		t.Fatal("Unexpected worker identity: ", description.Identity)
+	}
+	if taskRunner.SetWorkerIdStrategy(nil) == nil {
+		t.Fatal("Expected error for nil worker id strategy")
+	}
+}
@@ -10,10 +10,19 @@
 package unit_tests
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
 	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestSimpleTaskRunner(t *testing.T) {
@@ -52,3 +61,662 @@ func TestTaskRunnerWithAuthenticationSettings(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestTaskRunnerShutdown(t *testing.T) {
+	taskRunner := worker.NewTaskRunner(
+		nil,
+		settings.NewHttpSettings("http://localhost:1/api"),
+	)
+	err := taskRunner.StartWorker("unit_test_task", noopWorker, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report, err := taskRunner.Shutdown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.AbandonedTasks) != 0 {
+		t.Fatal("Unexpected abandoned tasks: ", report.AbandonedTasks)
+	}
+	taskRunner.WaitWorkers()
+	err = taskRunner.StartWorker("unit_test_task", noopWorker, 1, time.Second)
+	if err == nil {
+		t.Fatal("Expected error when starting a worker after shutdown")
+	}
+}
+
+func TestTaskRunnerShutdownDuringPoll(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	release := server.holdPolls()
+	defer release()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	err := taskRunner.StartWorker("unit_test_shutdown_poll_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for server.getPolls() < 1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_shutdown_poll_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	type shutdownResult struct {
+		report *worker.ShutdownReport
+		err    error
+	}
+	shutdownResults := make(chan shutdownResult, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		report, err := taskRunner.Shutdown(ctx)
+		shutdownResults <- shutdownResult{report, err}
+	}()
+	time.Sleep(100 * time.Millisecond)
+	release()
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok || taskResult.TaskId != "task_id" || taskResult.Status != model.CompletedTask {
+		t.Fatal("Expected the task polled during shutdown to be executed, got: ", taskResult)
+	}
+	result := <-shutdownResults
+	if result.err != nil || len(result.report.AbandonedTasks) != 0 {
+		t.Fatal("Expected graceful shutdown, got: ", result.err)
+	}
+}
+
+func TestTaskRunnerReportsPanic(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	taskRunner.SetPanicPolicy(worker.ReportPanicAsTerminalError)
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_panic_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	err := taskRunner.StartWorker("unit_test_panic_task", panicWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taskRunner.Shutdown(context.Background())
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Task was not updated after panic")
+	}
+	if taskResult.Status != model.FailedWithTerminalErrorTask {
+		t.Fatal("Unexpected task status: ", taskResult.Status)
+	}
+	if !strings.HasPrefix(taskResult.ReasonForIncompletion, "panic: unit test panic") {
+		t.Fatal("Unexpected reason for incompletion: ", taskResult.ReasonForIncompletion)
+	}
+	if len(taskResult.Logs) != 1 {
+		t.Fatal("Expected stack trace in the task logs")
+	}
+}
+
+func TestTaskRunnerHeartbeat(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetHeartbeatFraction(0.2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:            "unit_test_heartbeat_task",
+		TaskId:                 "task_id",
+		WorkflowInstanceId:     "workflow_id",
+		ResponseTimeoutSeconds: 1,
+	})
+	longRunningWorker := func(task *model.Task) (interface{}, error) {
+		err := taskRunner.SendHeartbeat(task, map[string]interface{}{"progress": 50}, 0)
+		if err != nil {
+			return nil, err
+		}
+		time.Sleep(500 * time.Millisecond)
+		return map[string]interface{}{"progress": 100}, nil
+	}
+	err = taskRunner.StartWorker("unit_test_heartbeat_task", longRunningWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	heartbeats := 0
+	for {
+		taskResult, ok := server.waitTaskResult(5 * time.Second)
+		if !ok {
+			t.Fatal("Task was not updated")
+		}
+		if taskResult.Status == model.CompletedTask {
+			break
+		}
+		if taskResult.Status != model.InProgressTask || taskResult.CallbackAfterSeconds != 1 || taskResult.OutputData["progress"] != float64(50) {
+			t.Fatal("Unexpected heartbeat: ", *taskResult)
+		}
+		heartbeats += 1
+	}
+	if heartbeats < 2 {
+		t.Fatal("Expected manual and automatic heartbeats, got: ", heartbeats)
+	}
+}
+
+func TestTaskRunnerExecutionDeadline(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	server.addTask(model.Task{
+		TaskDefName:            "unit_test_deadline_task",
+		TaskId:                 "task_id",
+		WorkflowInstanceId:     "workflow_id",
+		ResponseTimeoutSeconds: 1,
+	})
+	blockingWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	err := taskRunner.StartContextWorker("unit_test_deadline_task", blockingWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Task was not updated after its deadline")
+	}
+	if taskResult.Status != model.FailedTask || taskResult.ReasonForIncompletion != "task execution timed out after 1s" {
+		t.Fatal("Unexpected task result: ", *taskResult)
+	}
+}
+
+func TestExecutionTimeoutError(t *testing.T) {
+	timeoutError := error(&worker.ExecutionContextError{Cause: context.DeadlineExceeded, Timeout: time.Second})
+	if !errors.Is(timeoutError, worker.ErrExecutionTimeout) || !errors.Is(timeoutError, context.DeadlineExceeded) {
+		t.Fatal("Expected timeout error to match ErrExecutionTimeout and context.DeadlineExceeded: ", timeoutError)
+	}
+	if !strings.HasPrefix(timeoutError.Error(), worker.ErrExecutionTimeout.Error()) {
+		t.Fatal("Unexpected timeout error message: ", timeoutError.Error())
+	}
+	cancelledError := error(&worker.ExecutionContextError{Cause: context.Canceled})
+	if errors.Is(cancelledError, worker.ErrExecutionTimeout) {
+		t.Fatal("Cancelled execution matched ErrExecutionTimeout: ", cancelledError)
+	}
+}
+
+func TestTaskRunnerExecutionDeadlineWrappedError(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	server.addTask(model.Task{
+		TaskDefName:            "unit_test_deadline_wrapped_task",
+		TaskId:                 "task_id",
+		WorkflowInstanceId:     "workflow_id",
+		ResponseTimeoutSeconds: 1,
+	})
+	wrappingWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		<-ctx.Done()
+		return nil, fmt.Errorf("query interrupted: %w", ctx.Err())
+	}
+	err := taskRunner.StartContextWorker("unit_test_deadline_wrapped_task", wrappingWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Task was not updated after its deadline")
+	}
+	if taskResult.Status != model.FailedTask || taskResult.ReasonForIncompletion != "task execution timed out after 1s" {
+		t.Fatal("Unexpected task result: ", *taskResult)
+	}
+}
+
+func TestTaskRunnerAbandonedExecution(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	for i := 0; i < 2; i++ {
+		server.addTask(model.Task{
+			TaskDefName:            "unit_test_abandoned_task",
+			TaskId:                 fmt.Sprintf("task_id_%d", i),
+			WorkflowInstanceId:     "workflow_id",
+			ResponseTimeoutSeconds: 1,
+		})
+	}
+	abandonedBefore := getCounterValue(t, string(metrics.TASK_EXECUTION_ABANDONED), "unit_test_abandoned_task")
+	var started int32
+	release := make(chan struct{})
+	// Ignores its context, keeping running after the deadline
+	stubbornWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		atomic.AddInt32(&started, 1)
+		<-release
+		return nil, nil
+	}
+	err := taskRunner.StartContextWorker("unit_test_abandoned_task", stubbornWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok || taskResult.Status != model.FailedTask {
+		t.Fatal("Expected the task to fail after its deadline, got: ", taskResult)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if amount := atomic.LoadInt32(&started); amount != 1 {
+		t.Fatal("Expected no execution while the abandoned one is running, got: ", amount)
+	}
+	if abandoned := getCounterValue(t, string(metrics.TASK_EXECUTION_ABANDONED), "unit_test_abandoned_task"); abandoned-abandonedBefore != 1 {
+		t.Fatal("Expected abandoned execution to be counted, got: ", abandoned)
+	}
+	close(release)
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Expected the next task to be executed once the abandoned execution returned")
+	}
+	if amount := atomic.LoadInt32(&started); amount != 2 {
+		t.Fatal("Expected the next task to be executed, got executions: ", amount)
+	}
+}
+
+func TestTaskRunnerTaskLogs(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.SetTaskLogFlushInterval(10 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_logs_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	loggingWorker := func(ctx context.Context, task *model.Task) (interface{}, error) {
+		taskLogger := worker.GetTaskLogger(ctx)
+		taskLogger.Log("first line")
+		select {
+		case line := <-server.taskLogs:
+			if line != "first line" {
+				return nil, fmt.Errorf("unexpected flushed line: %s", line)
+			}
+		case <-time.After(5 * time.Second):
+			return nil, fmt.Errorf("first line was not flushed")
+		}
+		taskLogger.Logf("second %s", "line")
+		return nil, nil
+	}
+	err = taskRunner.StartContextWorker("unit_test_logs_task", loggingWorker, 1, 10*time.Millisecond, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Task was not updated")
+	}
+	if taskResult.Status != model.CompletedTask {
+		t.Fatal("Unexpected task result: ", *taskResult)
+	}
+	if len(taskResult.Logs) != 1 || taskResult.Logs[0].Log != "second line" || taskResult.Logs[0].TaskId != "task_id" {
+		t.Fatal("Unexpected task logs: ", taskResult.Logs)
+	}
+}
+
+type declarativeWorker struct {
+	taskName  string
+	batchSize int
+}
+
+func (w *declarativeWorker) TaskName() string                           { return w.taskName }
+func (w *declarativeWorker) Execute(t *model.Task) (interface{}, error) { return nil, nil }
+func (w *declarativeWorker) BatchSize() int                             { return w.batchSize }
+func (w *declarativeWorker) PollInterval() time.Duration                { return time.Second }
+func (w *declarativeWorker) Domain() string                             { return "" }
+func (w *declarativeWorker) Identity() string                           { return "unit_test_worker" }
+
+func TestTaskRunnerWorkerRegistry(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.StartWorkers(
+		&declarativeWorker{taskName: "unit_test_task_b", batchSize: 1},
+		&declarativeWorker{taskName: "unit_test_task_a", batchSize: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workers := taskRunner.ListWorkers()
+	if len(workers) != 2 || workers[0].TaskName != "unit_test_task_a" || workers[0].BatchSize != 2 {
+		t.Fatal("Unexpected workers: ", workers)
+	}
+	err = taskRunner.ReconfigureWorker(&declarativeWorker{taskName: "unit_test_task_a", batchSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	description, err := taskRunner.DescribeWorker("unit_test_task_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description.BatchSize != 5 || description.Identity != "unit_test_worker" {
+		t.Fatal("Unexpected worker description: ", *description)
+	}
+	err = taskRunner.ReconfigureWorker(&declarativeWorker{taskName: "unit_test_task_c", batchSize: 1})
+	if err == nil {
+		t.Fatal("Expected error when reconfiguring an unknown worker")
+	}
+	err = taskRunner.StartWorkers(&declarativeWorker{taskName: "unit_test_task_d", batchSize: 0})
+	if err == nil {
+		t.Fatal("Expected error when starting a worker without batch size")
+	}
+}
+
+func TestTaskRunnerPauseWorker(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	t.Setenv("CONDUCTOR_WORKER_UNIT_TEST_PAUSED_TASK_PAUSED", "true")
+	err := taskRunner.StartWorker("unit_test_paused_task", noopWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !taskRunner.IsWorkerPaused("unit_test_paused_task") {
+		t.Fatal("Expected worker paused by environment variable")
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_paused_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	if _, ok := server.waitTaskResult(200 * time.Millisecond); ok {
+		t.Fatal("Paused worker polled a task")
+	}
+	description, err := taskRunner.DescribeWorker("unit_test_paused_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !description.Paused || description.BatchSize != 1 {
+		t.Fatal("Unexpected worker description: ", *description)
+	}
+	err = taskRunner.ResumeWorker("unit_test_paused_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Resumed worker did not poll the task")
+	}
+	err = taskRunner.PauseWorker("unit_test_paused_task")
+	if err != nil || !taskRunner.IsWorkerPaused("unit_test_paused_task") {
+		t.Fatal("Failed to pause worker: ", err)
+	}
+	err = taskRunner.PauseWorker("unit_test_unknown_task")
+	if err == nil {
+		t.Fatal("Expected error when pausing an unknown worker")
+	}
+}
+
+func TestTaskRunnerDecreaseBatchSizeToZero(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.StartWorker("unit_test_zero_batch_task", noopWorker, 2, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.DecreaseBatchSize("unit_test_zero_batch_task", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batchSize := taskRunner.GetBatchSizeForTask("unit_test_zero_batch_task"); batchSize != 0 {
+		t.Fatal("Expected batch size of 0, got: ", batchSize)
+	}
+	time.Sleep(100 * time.Millisecond)
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_zero_batch_task",
+		TaskId:             "task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	if _, ok := server.waitTaskResult(200 * time.Millisecond); ok {
+		t.Fatal("Worker with a batch size of 0 polled a task")
+	}
+	err = taskRunner.IncreaseBatchSize("unit_test_zero_batch_task", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Worker did not poll again once its batch size was increased")
+	}
+	err = taskRunner.SetBatchSize("unit_test_zero_batch_task", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_zero_batch_task",
+		TaskId:             "second_task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	time.Sleep(100 * time.Millisecond)
+	err = taskRunner.SetBatchSize("unit_test_zero_batch_task", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.waitTaskResult(5 * time.Second); !ok {
+		t.Fatal("Worker did not poll again once its batch size was set")
+	}
+}
+
+type redactingMiddleware struct {
+	worker.BaseMiddleware
+	polls   int32
+	updates chan error
+}
+
+func (m *redactingMiddleware) BeforePoll(taskName string, domain string) error {
+	atomic.AddInt32(&m.polls, 1)
+	return nil
+}
+
+func (m *redactingMiddleware) AroundExecute(taskName string, next model.ExecuteTaskFunctionWithContext) model.ExecuteTaskFunctionWithContext {
+	return func(ctx context.Context, t *model.Task) (interface{}, error) {
+		if _, ok := t.InputData["email"]; !ok {
+			return nil, fmt.Errorf("missing email")
+		}
+		return next(ctx, t)
+	}
+}
+
+func (m *redactingMiddleware) BeforeUpdate(t *model.Task, taskResult *model.TaskResult) {
+	if _, ok := taskResult.OutputData["email"]; ok {
+		taskResult.OutputData["email"] = "REDACTED"
+	}
+}
+
+func (m *redactingMiddleware) AfterUpdate(t *model.Task, taskResult *model.TaskResult, err error) {
+	m.updates <- err
+}
+
+func TestTaskRunnerMiddleware(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	middleware := &redactingMiddleware{updates: make(chan error, 2)}
+	taskRunner.AddMiddleware(middleware)
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_middleware_task",
+		TaskId:             "valid_task_id",
+		WorkflowInstanceId: "workflow_id",
+		InputData:          map[string]interface{}{"email": "user@example.com"},
+	})
+	server.addTask(model.Task{
+		TaskDefName:        "unit_test_middleware_task",
+		TaskId:             "invalid_task_id",
+		WorkflowInstanceId: "workflow_id",
+	})
+	echoWorker := func(task *model.Task) (interface{}, error) {
+		return task.InputData, nil
+	}
+	err := taskRunner.StartWorker("unit_test_middleware_task", echoWorker, 2, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		taskResult, ok := server.waitTaskResult(5 * time.Second)
+		if !ok {
+			t.Fatal("Task was not updated")
+		}
+		switch taskResult.TaskId {
+		case "valid_task_id":
+			if taskResult.Status != model.CompletedTask || taskResult.OutputData["email"] != "REDACTED" {
+				t.Fatal("Unexpected task result: ", *taskResult)
+			}
+		case "invalid_task_id":
+			if taskResult.Status != model.FailedTask || taskResult.ReasonForIncompletion != "missing email" {
+				t.Fatal("Unexpected task result: ", *taskResult)
+			}
+		}
+		select {
+		case err := <-middleware.updates:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("AfterUpdate was not called")
+		}
+	}
+	if atomic.LoadInt32(&middleware.polls) < 1 {
+		t.Fatal("BeforePoll was not called")
+	}
+}
+
+func TestTaskRunnerMiddlewareHeartbeat(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	taskRunner.AddMiddleware(&redactingMiddleware{updates: make(chan error, 1)})
+	server.addTask(model.Task{
+		TaskDefName:            "unit_test_middleware_heartbeat_task",
+		TaskId:                 "task_id",
+		WorkflowInstanceId:     "workflow_id",
+		InputData:              map[string]interface{}{"email": "user@example.com"},
+		ResponseTimeoutSeconds: 10,
+	})
+	partialOutput := map[string]interface{}{"email": "user@example.com"}
+	heartbeatWorker := func(task *model.Task) (interface{}, error) {
+		err := taskRunner.SendHeartbeat(task, partialOutput, 0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	err := taskRunner.StartWorker("unit_test_middleware_heartbeat_task", heartbeatWorker, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskResult, ok := server.waitTaskResult(5 * time.Second)
+	if !ok {
+		t.Fatal("Heartbeat was not sent")
+	}
+	if taskResult.Status != model.InProgressTask || taskResult.OutputData["email"] != "REDACTED" {
+		t.Fatal("Expected redacted heartbeat, got: ", *taskResult)
+	}
+	if partialOutput["email"] != "user@example.com" {
+		t.Fatal("Expected the partial output of the worker unchanged, got: ", partialOutput)
+	}
+}
+
+func panicWorker(t *model.Task) (interface{}, error) {
+	panic("unit test panic")
+}
+
+func noopWorker(t *model.Task) (interface{}, error) {
+	return nil, nil
+}
+
+func TestTaskRunnerSingleDomainByTaskName(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	err := taskRunner.StartWorkerWithDomain("unit_test_single_domain_task", noopWorker, 1, time.Second, "domain_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.IncreaseBatchSize("unit_test_single_domain_task", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batchSize := taskRunner.GetBatchSizeForTask("unit_test_single_domain_task"); batchSize != 3 {
+		t.Fatal("Expected batch size of 3, got: ", batchSize)
+	}
+	err = taskRunner.SetBatchSize("unit_test_single_domain_task", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	description, err := taskRunner.DescribeWorker("unit_test_single_domain_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description.Name != "domain_a:unit_test_single_domain_task" || description.BatchSize != 5 {
+		t.Fatal("Unexpected worker description: ", *description)
+	}
+	err = taskRunner.StartWorkerWithDomain("unit_test_single_domain_task", noopWorker, 1, time.Second, "domain_b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taskRunner.SetBatchSize("unit_test_single_domain_task", 5) == nil {
+		t.Fatal("Expected error when the task name matches several workers")
+	}
+}
+
+func TestTaskRunnerMultipleDomains(t *testing.T) {
+	server := newConductorServer()
+	defer server.close()
+	taskRunner := worker.NewTaskRunner(nil, server.httpSettings())
+	defer taskRunner.Shutdown(context.Background())
+	for _, domain := range []string{"domain_a", "domain_a", "domain_b", "domain_b", "domain_c"} {
+		server.addTask(model.Task{
+			TaskDefName:        "unit_test_domain_task",
+			TaskId:             domain,
+			WorkflowInstanceId: "workflow_id",
+			Domain:             domain,
+		})
+	}
+	err := taskRunner.StartWorkerWithDomains("unit_test_domain_task", noopWorker, 1, time.Second, []string{"domain_a", "domain_b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = taskRunner.StartWorkerWithDomain("unit_test_domain_task", noopWorker, 1, time.Second, "domain_c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var polledByDomains []string
+	var polledByDomainC bool
+	for i := 0; i < 5; i++ {
+		taskResult, ok := server.waitTaskResult(5 * time.Second)
+		if !ok {
+			t.Fatal("Timed out waiting for task result")
+		}
+		if taskResult.TaskId == "domain_c" {
+			polledByDomainC = true
+		} else {
+			polledByDomains = append(polledByDomains, taskResult.TaskId)
+		}
+	}
+	expected := []string{"domain_a", "domain_b", "domain_a", "domain_b"}
+	if !polledByDomainC || !reflect.DeepEqual(polledByDomains, expected) {
+		t.Fatal("Expected domains polled in turn, got: ", polledByDomains, ", domain_c: ", polledByDomainC)
+	}
+	workers := taskRunner.ListWorkers()
+	if len(workers) != 2 || workers[0].Name != "domain_a,domain_b:unit_test_domain_task" || workers[1].Name != "domain_c:unit_test_domain_task" {
+		t.Fatal("Unexpected workers: ", workers)
+	}
+	if taskRunner.GetBatchSizeForTask(worker.WorkerName("unit_test_domain_task", "domain_c")) != 1 {
+		t.Fatal("Expected a batch size of 1 for the worker of domain_c")
+	}
+}